@@ -0,0 +1,164 @@
+// Package analyzer adapts tfprovlint rules to the golang.org/x/tools/go/analysis
+// API so they can be run by go vet, golangci-lint, or editors.
+package analyzer
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// ProviderAnalyzer parses the provider model of a package. Its result is a
+// *provparse.Provider, or nil if the package does not declare a provider.
+var ProviderAnalyzer = &analysis.Analyzer{
+	Name:       "tfprovlintprovider",
+	Doc:        "parses the Terraform provider declared in a package",
+	Requires:   []*analysis.Analyzer{buildssa.Analyzer},
+	ResultType: reflect.TypeOf((*provparse.Provider)(nil)),
+	Run:        runProvider,
+}
+
+func runProvider(pass *analysis.Pass) (interface{}, error) {
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+	// only the package exporting the Provider func is linted
+	if ssaInput.Pkg.Func("Provider") == nil {
+		return (*provparse.Provider)(nil), nil
+	}
+
+	return provparse.SSAPackage(ssaInput.Pkg, pass.Files)
+}
+
+// NewResourceRuleAnalyzer returns an analyzer that checks every resource and
+// data source of the provider with the rule.
+func NewResourceRuleAnalyzer(name, doc string, factory func() lint.ResourceRule) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     name,
+		Doc:      doc,
+		Requires: []*analysis.Analyzer{ProviderAnalyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			prov := pass.ResultOf[ProviderAnalyzer].(*provparse.Provider)
+			if prov == nil {
+				return nil, nil
+			}
+
+			for _, t := range []struct {
+				readOnly  bool
+				resources []provparse.Resource
+			}{
+				{true, prov.DataSources},
+				{false, prov.Resources},
+			} {
+				for i := range t.resources {
+					r := &t.resources[i]
+					label := r.Name
+					if t.readOnly {
+						label = "data." + label
+					}
+					issues, err := checkResource(factory(), t.readOnly, r)
+					if err != nil {
+						// report the failure and continue with the other resources
+						pass.Report(internalErrorDiagnostic(label, name, r.Pos(), err))
+						continue
+					}
+					for _, iss := range issues {
+						pass.Report(newDiagnostic(label, iss))
+					}
+				}
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+// NewProviderRuleAnalyzer returns an analyzer that checks the provider with the
+// rule.
+func NewProviderRuleAnalyzer(name, doc string, factory func() lint.ProviderRule) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     name,
+		Doc:      doc,
+		Requires: []*analysis.Analyzer{ProviderAnalyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			prov := pass.ResultOf[ProviderAnalyzer].(*provparse.Provider)
+			if prov == nil {
+				return nil, nil
+			}
+
+			issues, err := checkProvider(factory(), prov)
+			if err != nil {
+				pass.Report(internalErrorDiagnostic("provider", name, prov.Pos(), err))
+				return nil, nil
+			}
+			for _, iss := range issues {
+				pass.Report(newDiagnostic("provider", iss))
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+// checkResource runs the rule, recovering from panics so a failing rule does not
+// abort the pass.
+func checkResource(rule lint.ResourceRule, readOnly bool, r *provparse.Resource) (issues []lint.Issue, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			issues, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return rule.CheckResource(readOnly, r)
+}
+
+// checkProvider runs the rule, recovering from panics so a failing rule does not
+// abort the pass.
+func checkProvider(rule lint.ProviderRule, prov *provparse.Provider) (issues []lint.Issue, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			issues, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return rule.CheckProvider(prov)
+}
+
+// internalErrorDiagnostic reports the failure of a rule, the message matches the
+// internal errors reported by the lint command.
+func internalErrorDiagnostic(label, name string, pos token.Pos, err error) analysis.Diagnostic {
+	return newDiagnostic(label, lint.Issue{
+		Pos:     pos,
+		Message: fmt.Sprintf("internal error checking %s with %s: %s", label, name, err),
+	})
+}
+
+func newDiagnostic(label string, iss lint.Issue) analysis.Diagnostic {
+	d := analysis.Diagnostic{
+		Pos:     iss.Pos,
+		Message: "[" + label + "] " + iss.Message,
+	}
+	for _, fix := range iss.Fixes {
+		sf := analysis.SuggestedFix{
+			Message: fix.Message,
+		}
+		for _, e := range fix.Edits {
+			sf.TextEdits = append(sf.TextEdits, analysis.TextEdit{
+				Pos:     e.Pos,
+				End:     e.End,
+				NewText: e.NewText,
+			})
+		}
+		d.SuggestedFixes = append(d.SuggestedFixes, sf)
+	}
+	for _, rel := range iss.Related {
+		d.Related = append(d.Related, analysis.RelatedInformation{
+			Pos:     rel.Pos,
+			Message: rel.Message,
+		})
+	}
+	return d
+}
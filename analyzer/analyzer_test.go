@@ -0,0 +1,51 @@
+package analyzer_test
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/paultyng/tfprovlint/analyzer"
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+	"github.com/paultyng/tfprovlint/rules"
+)
+
+func TestResourceRuleAnalyzer(t *testing.T) {
+	a := analyzer.NewResourceRuleAnalyzer("tfprovlint002", "test", rules.NewSetAttributeNameExistsRule)
+	analysistest.Run(t, analysistest.TestData(), a, "resourcerule")
+}
+
+func TestProviderRuleAnalyzer(t *testing.T) {
+	a := analyzer.NewProviderRuleAnalyzer("tfprovlint030", "test", rules.NewResourceNamePrefixRule)
+	analysistest.Run(t, analysistest.TestData(), a, "providerrule")
+}
+
+type failingRule struct{}
+
+func (rule *failingRule) CheckResource(readOnly bool, r *provparse.Resource) ([]lint.Issue, error) {
+	switch r.Name {
+	case "example_error":
+		return nil, errors.New("resource rule failed")
+	case "example_panic":
+		var attrs []provparse.Attribute
+		_ = attrs[1]
+	}
+	return []lint.Issue{lint.NewIssuef(r.Pos(), "checked")}, nil
+}
+
+func (rule *failingRule) CheckProvider(p *provparse.Provider) ([]lint.Issue, error) {
+	panic("provider rule failed")
+}
+
+func TestRuleAnalyzer_internalError(t *testing.T) {
+	resourceAnalyzer := analyzer.NewResourceRuleAnalyzer("testresource", "test", func() lint.ResourceRule {
+		return &failingRule{}
+	})
+	providerAnalyzer := analyzer.NewProviderRuleAnalyzer("testprovider", "test", func() lint.ProviderRule {
+		return &failingRule{}
+	})
+	analysistest.Run(t, analysistest.TestData(), resourceAnalyzer, "internalerror")
+	analysistest.Run(t, analysistest.TestData(), providerAnalyzer, "providerinternalerror")
+}
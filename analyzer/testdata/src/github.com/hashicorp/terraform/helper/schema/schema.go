@@ -0,0 +1,50 @@
+// Package schema is a minimal stub of the Terraform helper/schema package.
+package schema
+
+type ValueType int
+
+const (
+	TypeInvalid ValueType = iota
+	TypeBool
+	TypeInt
+	TypeFloat
+	TypeString
+	TypeList
+	TypeMap
+	TypeSet
+)
+
+type Schema struct {
+	Type     ValueType
+	Optional bool
+	Required bool
+	Computed bool
+	Elem     interface{}
+}
+
+type CreateFunc func(*ResourceData, interface{}) error
+type ReadFunc func(*ResourceData, interface{}) error
+type UpdateFunc func(*ResourceData, interface{}) error
+type DeleteFunc func(*ResourceData, interface{}) error
+
+type Resource struct {
+	Schema map[string]*Schema
+	Create CreateFunc
+	Read   ReadFunc
+	Update UpdateFunc
+	Delete DeleteFunc
+}
+
+type Provider struct {
+	Schema         map[string]*Schema
+	ResourcesMap   map[string]*Resource
+	DataSourcesMap map[string]*Resource
+}
+
+type ResourceData struct{}
+
+func (d *ResourceData) Id() string { return "" }
+
+func (d *ResourceData) SetId(string) {}
+
+func (d *ResourceData) Set(string, interface{}) error { return nil }
@@ -0,0 +1,31 @@
+package internalerror
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"example_error": resourceExampleError(),
+			"example_ok":    resourceExampleOK(),
+			"example_panic": resourceExamplePanic(),
+		},
+	}
+}
+
+func resourceExampleError() *schema.Resource { // want `\[example_error\] internal error checking example_error with testresource: resource rule failed`
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+	}
+}
+
+func resourceExampleOK() *schema.Resource { // want `\[example_ok\] checked`
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+	}
+}
+
+func resourceExamplePanic() *schema.Resource { // want `\[example_panic\] internal error checking example_panic with testresource: panic: `
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+	}
+}
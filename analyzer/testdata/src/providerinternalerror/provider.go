@@ -0,0 +1,9 @@
+package providerinternalerror
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider { // want `\[provider\] internal error checking provider with testprovider: panic: provider rule failed`
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{},
+	}
+}
@@ -0,0 +1,25 @@
+package providerrule
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"example_thing": resourceExampleThing(),
+			"example_other": resourceExampleThing(),
+			"other_thing":   resourceOtherThing(),
+		},
+	}
+}
+
+func resourceExampleThing() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+	}
+}
+
+func resourceOtherThing() *schema.Resource { // want `\[provider\] resource "other_thing" should start with the provider name prefix "example_"`
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+	}
+}
@@ -0,0 +1,32 @@
+package resourcerule
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		DataSourcesMap: map[string]*schema.Resource{
+			"example_thing": resourceExampleThing(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"example_thing": resourceExampleThing(),
+		},
+	}
+}
+
+func resourceExampleThing() *schema.Resource {
+	return &schema.Resource{
+		Read: resourceExampleThingRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceExampleThingRead(d *schema.ResourceData, meta interface{}) error {
+	d.Set("name", "foo")
+	d.Set("nmae", "foo") // want `\[data.example_thing\] attribute "nmae" was not read from the schema` `\[example_thing\] attribute "nmae" was not read from the schema`
+	return nil
+}
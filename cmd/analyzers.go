@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/paultyng/tfprovlint/analyzer"
+)
+
+// Analyzers returns a go/analysis Analyzer for each registered rule, named by
+// rule ID, for use with go vet or other analysis drivers.
+func Analyzers() []*analysis.Analyzer {
+	var analyzers []*analysis.Analyzer
+	for _, id := range sortedRuleIDs(knownRuleIDs()) {
+		doc := analyzerDoc(id)
+		if factory, ok := resourceRules[id]; ok {
+			analyzers = append(analyzers, analyzer.NewResourceRuleAnalyzer(id, doc, factory))
+		}
+		if factory, ok := providerRules[id]; ok {
+			name := id
+			if _, ok := resourceRules[id]; ok {
+				// analyzer names must be unique
+				name += "provider"
+			}
+			analyzers = append(analyzers, analyzer.NewProviderRuleAnalyzer(name, doc, factory))
+		}
+	}
+	return analyzers
+}
+
+func analyzerDoc(id string) string {
+	info := ruleInfos[id]
+	if info.Doc.Rationale == "" {
+		return info.Description
+	}
+	return info.Description + "\n\n" + info.Doc.Rationale
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// baselineVersion is incremented whenever a backwards incompatible change is
+// made to the baseline file format.
+const baselineVersion = 1
+
+type baselineFile struct {
+	Version int             `json:"version"`
+	Issues  []baselineIssue `json:"issues"`
+}
+
+type baselineIssue struct {
+	baselineFingerprint
+	Count int `json:"count"`
+}
+
+// baselineFingerprint identifies an issue independently of its position so that
+// it remains stable as unrelated code is changed.
+type baselineFingerprint struct {
+	RuleID     string `json:"rule_id"`
+	Resource   string `json:"resource"`
+	DataSource bool   `json:"data_source"`
+	Attribute  string `json:"attribute,omitempty"`
+	Message    string `json:"message"`
+}
+
+func newBaselineFingerprint(res issueResult) baselineFingerprint {
+	return baselineFingerprint{
+		RuleID:     res.RuleID,
+		Resource:   res.resourceName(),
+		DataSource: res.ReadOnly,
+		Attribute:  res.Issue.AttributePath,
+		Message:    strings.Join(strings.Fields(res.Issue.Message), " "),
+	}
+}
+
+// baseline is the count of known issues by fingerprint.
+type baseline map[baselineFingerprint]int
+
+func newBaseline(results []issueResult) baseline {
+	b := baseline{}
+	for _, res := range results {
+		if res.Internal {
+			// internal errors are never baselined
+			continue
+		}
+		b[newBaselineFingerprint(res)]++
+	}
+	return b
+}
+
+func readBaseline(path string) (baseline, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f baselineFile
+	err = json.Unmarshal(src, &f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse baseline %s: %s", path, err)
+	}
+	if f.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", f.Version, path)
+	}
+
+	b := baseline{}
+	for _, iss := range f.Issues {
+		b[iss.baselineFingerprint] += iss.Count
+	}
+	return b, nil
+}
+
+func (b baseline) write(path string) error {
+	issues := make([]baselineIssue, 0, len(b))
+	for fp, count := range b {
+		issues = append(issues, baselineIssue{
+			baselineFingerprint: fp,
+			Count:               count,
+		})
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		switch {
+		case a.Resource != b.Resource:
+			return a.Resource < b.Resource
+		case a.DataSource != b.DataSource:
+			return !a.DataSource
+		case a.RuleID != b.RuleID:
+			return a.RuleID < b.RuleID
+		case a.Attribute != b.Attribute:
+			return a.Attribute < b.Attribute
+		}
+		return a.Message < b.Message
+	})
+
+	src, err := json.MarshalIndent(&baselineFile{
+		Version: baselineVersion,
+		Issues:  issues,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(src, '\n'), 0644)
+}
+
+// filter removes results that are recorded in the baseline. If an issue occurs
+// more times than recorded, the additional occurrences are kept. Baselines
+// written before issues had attribute paths match regardless of the attribute.
+func (b baseline) filter(results []issueResult) []issueResult {
+	remaining := make(baseline, len(b))
+	for fp, count := range b {
+		remaining[fp] = count
+	}
+
+	filtered := make([]issueResult, 0, len(results))
+	for _, res := range results {
+		fp := newBaselineFingerprint(res)
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			continue
+		}
+		fp.Attribute = ""
+		if remaining[fp] > 0 {
+			remaining[fp]--
+			continue
+		}
+		filtered = append(filtered, res)
+	}
+	return filtered
+}
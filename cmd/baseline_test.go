@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+func TestBaseline(t *testing.T) {
+	result := func(readOnly bool, resource, ruleID, msg string) issueResult {
+		return issueResult{
+			ReadOnly: readOnly,
+			Resource: &provparse.Resource{Name: resource},
+			RuleID:   ruleID,
+			Issue:    lint.Issue{Message: msg},
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "tfprovlint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "baseline.json")
+
+	err = newBaseline([]issueResult{
+		result(false, "a", "tfprovlint002", `attribute "foo" was not read from the schema`),
+		result(false, "a", "tfprovlint002", `attribute "foo" was not read from the schema`),
+		result(true, "a", "tfprovlint003", `attribute "bar" expects a d.Set compatible with TypeInt`),
+	}).write(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := readBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := b.filter([]issueResult{
+		// whitespace differences are ignored
+		result(false, "a", "tfprovlint002", "attribute \"foo\"  was not read\nfrom the schema"),
+		result(false, "a", "tfprovlint002", `attribute "foo" was not read from the schema`),
+		// only two were in the baseline
+		result(false, "a", "tfprovlint002", `attribute "foo" was not read from the schema`),
+		// resource instead of data source
+		result(false, "a", "tfprovlint003", `attribute "bar" expects a d.Set compatible with TypeInt`),
+		result(true, "a", "tfprovlint003", `attribute "bar" expects a d.Set compatible with TypeInt`),
+		result(true, "b", "tfprovlint003", `attribute "bar" expects a d.Set compatible with TypeInt`),
+	})
+
+	expected := []string{
+		"a tfprovlint002",
+		"a tfprovlint003",
+		"data.b tfprovlint003",
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d results, found %d", len(expected), len(actual))
+	}
+	for i, res := range actual {
+		if key := res.resourceLabel() + " " + res.RuleID; key != expected[i] {
+			t.Fatalf("expected %q, found %q", expected[i], key)
+		}
+	}
+}
+
+func TestBaseline_attributePath(t *testing.T) {
+	result := func(attributePath string) issueResult {
+		return issueResult{
+			Resource: &provparse.Resource{Name: "a"},
+			RuleID:   "tfprovlint002",
+			Issue: lint.Issue{
+				Message:       `attribute "foo" was not read from the schema`,
+				AttributePath: attributePath,
+			},
+		}
+	}
+
+	b := newBaseline([]issueResult{
+		result("foo"),
+		// baselines written before attribute paths were recorded
+		result(""),
+	})
+
+	actual := b.filter([]issueResult{
+		result("foo"),
+		result("bar"),
+		result("bar"),
+	})
+	if len(actual) != 1 || actual[0].Issue.AttributePath != "bar" {
+		t.Fatalf("expected a single unmatched result for bar, found %d", len(actual))
+	}
+}
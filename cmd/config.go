@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/plugin"
+	"github.com/paultyng/tfprovlint/provparse"
+	"github.com/paultyng/tfprovlint/rules"
+)
+
+const configFileName = ".tfprovlint.hcl"
+
+// config is the project configuration read from a .tfprovlint.hcl file.
+type config struct {
+	Rules          []ruleConfig     `hcl:"rule"`
+	CallBlacklists []callRuleConfig `hcl:"call_blacklist"`
+	CallRequired   []callRuleConfig `hcl:"call_required"`
+	Plugins        []pluginConfig   `hcl:"plugin"`
+
+	// ExcludeResources is a list of resource names (or name patterns) to skip
+	// linting, data sources are prefixed with "data.".
+	ExcludeResources []string `hcl:"exclude_resources"`
+
+	// ExcludeFiles is a list of file patterns, relative to the configuration
+	// file, to ignore issues in. Patterns without a "/" match the file name only.
+	ExcludeFiles []string `hcl:"exclude_files"`
+
+	dir         string
+	rules       map[string]ruleConfig
+	customRules map[string]ruleInfo
+}
+
+type ruleConfig struct {
+	ID       string `hcl:",key"`
+	Enabled  *bool  `hcl:"enabled"`
+	Severity string `hcl:"severity"`
+
+	severity lint.Severity
+}
+
+// callRuleConfig defines a rule that reports calls to functions from resource
+// functions (a call_blacklist block, see rules.NewCallBlacklistRule), or
+// resource functions that do not call them (a call_required block, see
+// rules.NewCallRequiredRule).
+type callRuleConfig struct {
+	ID          string `hcl:",key"`
+	Description string `hcl:"description"`
+	// Message is the issue message, %s is replaced with the function name.
+	Message  string `hcl:"message"`
+	Severity string `hcl:"severity"`
+
+	// All functions apply to every resource function.
+	All    []string `hcl:"all"`
+	Create []string `hcl:"create"`
+	Read   []string `hcl:"read"`
+	Update []string `hcl:"update"`
+	Delete []string `hcl:"delete"`
+	Exists []string `hcl:"exists"`
+}
+
+const (
+	defaultCallBlacklistMessage = "resource functions should not call %s"
+	defaultCallRequiredMessage  = "resource function should call %s"
+)
+
+func (bc *callRuleConfig) callList() rules.CallList {
+	return rules.CallList{
+		Create: bc.withAll(bc.Create),
+		Read:   bc.withAll(bc.Read),
+		Update: bc.withAll(bc.Update),
+		Delete: bc.withAll(bc.Delete),
+		Exists: bc.withAll(bc.Exists),
+	}
+}
+
+func (bc *callRuleConfig) withAll(funcs []string) []string {
+	combined := make([]string, 0, len(funcs)+len(bc.All))
+	combined = append(combined, funcs...)
+	return append(combined, bc.All...)
+}
+
+func (bc *callRuleConfig) validate() error {
+	// only a single %s verb is allowed as the message is used as a format
+	if strings.Count(bc.Message, "%s") != 1 || strings.Contains(strings.Replace(strings.Replace(bc.Message, "%%", "", -1), "%s", "", 1), "%") {
+		return fmt.Errorf("message must contain a single %%s for the function name")
+	}
+	if len(bc.All)+len(bc.Create)+len(bc.Read)+len(bc.Update)+len(bc.Delete)+len(bc.Exists) == 0 {
+		return fmt.Errorf("no functions listed")
+	}
+	return nil
+}
+
+type pluginConfig struct {
+	Name string `hcl:",key"`
+	// Command is the path of the plugin executable, relative paths containing
+	// a "/" are relative to the configuration file, others are looked up in PATH.
+	Command string   `hcl:"command"`
+	Args    []string `hcl:"args"`
+}
+
+// findConfigFile walks up from dir, stopping at the module root, looking for a
+// configuration file. An empty string is returned if none is found.
+func findConfigFile(dir string) string {
+	root := moduleRoot(dir)
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		file := filepath.Join(dir, configFileName)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+		parent := filepath.Dir(dir)
+		if dir == root || parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadConfig loads the configuration file at path or, if path is empty, the
+// configuration file discovered from the provider's directory. An empty
+// configuration is returned if no file is found.
+func loadConfig(path string, prov *provparse.Provider) (*config, error) {
+	if path == "" {
+		provDir := filepath.Dir(prov.Fset.Position(prov.Pos()).Filename)
+		path = findConfigFile(provDir)
+		if path == "" {
+			return &config{}, nil
+		}
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := parseConfig(string(src), filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s: %s", path, err)
+	}
+	return cfg, nil
+}
+
+func parseConfig(src string, dir string) (*config, error) {
+	cfg := &config{}
+	err := hcl.Decode(cfg, src)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.dir = dir
+	cfg.rules = make(map[string]ruleConfig, len(cfg.Rules))
+	for _, rc := range cfg.Rules {
+		if _, ok := cfg.rules[rc.ID]; ok {
+			return nil, fmt.Errorf("rule %q is configured more than once", rc.ID)
+		}
+		if rc.Severity != "" {
+			rc.severity, err = lint.ParseSeverity(rc.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %s", rc.ID, err)
+			}
+		}
+		cfg.rules[rc.ID] = rc
+	}
+
+	known := knownRuleIDs()
+	cfg.customRules = make(map[string]ruleInfo, len(cfg.CallBlacklists)+len(cfg.CallRequired))
+	for _, block := range []struct {
+		name           string
+		defaultMessage string
+		rules          []callRuleConfig
+	}{
+		{"call_blacklist", defaultCallBlacklistMessage, cfg.CallBlacklists},
+		{"call_required", defaultCallRequiredMessage, cfg.CallRequired},
+	} {
+		for i := range block.rules {
+			bc := &block.rules[i]
+			if known[bc.ID] {
+				return nil, fmt.Errorf("%s %q: rule is already defined", block.name, bc.ID)
+			}
+			known[bc.ID] = true
+			if bc.Message == "" {
+				bc.Message = block.defaultMessage
+			}
+			if err := bc.validate(); err != nil {
+				return nil, fmt.Errorf("%s %q: %s", block.name, bc.ID, err)
+			}
+			info := ruleInfo{
+				Description: bc.Description,
+				Category:    categoryRuntime,
+			}
+			if bc.Severity != "" {
+				info.Severity, err = lint.ParseSeverity(bc.Severity)
+				if err != nil {
+					return nil, fmt.Errorf("%s %q: %s", block.name, bc.ID, err)
+				}
+			}
+			cfg.customRules[bc.ID] = info
+		}
+	}
+
+	for i, pc := range cfg.Plugins {
+		if pc.Command == "" {
+			return nil, fmt.Errorf("plugin %q has no command", pc.Name)
+		}
+		if strings.Contains(pc.Command, "/") && !filepath.IsAbs(pc.Command) {
+			cfg.Plugins[i].Command = filepath.Join(dir, pc.Command)
+		}
+	}
+
+	for _, pattern := range append(cfg.ExcludeResources, cfg.ExcludeFiles...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %s", pattern, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// resourceRules returns the built-in resource rules and the rules defined in
+// the configuration.
+func (cfg *config) resourceRules() map[string]ruleFactoryFunc {
+	factories := make(map[string]ruleFactoryFunc, len(resourceRules)+len(cfg.CallBlacklists)+len(cfg.CallRequired))
+	for id, factory := range resourceRules {
+		factories[id] = factory
+	}
+	for _, bc := range cfg.CallBlacklists {
+		bc := bc
+		factories[bc.ID] = func() lint.ResourceRule {
+			return rules.NewCallBlacklistRule(bc.Message, bc.callList())
+		}
+	}
+	for _, bc := range cfg.CallRequired {
+		bc := bc
+		factories[bc.ID] = func() lint.ResourceRule {
+			return rules.NewCallRequiredRule(bc.Message, bc.callList())
+		}
+	}
+	return factories
+}
+
+// pluginClients returns a client for each configured plugin.
+func (cfg *config) pluginClients() []*plugin.Client {
+	clients := make([]*plugin.Client, 0, len(cfg.Plugins))
+	for _, pc := range cfg.Plugins {
+		clients = append(clients, &plugin.Client{
+			Command: pc.Command,
+			Args:    pc.Args,
+		})
+	}
+	return clients
+}
+
+// validateRuleIDs returns an error if any configured rule is not known.
+func (cfg *config) validateRuleIDs(known map[string]bool) error {
+	for _, rc := range cfg.Rules {
+		if !known[rc.ID] {
+			return fmt.Errorf("unknown rule %q in configuration", rc.ID)
+		}
+	}
+	return nil
+}
+
+func (cfg *config) ruleEnabled(id string) bool {
+	rc, ok := cfg.rules[id]
+	if !ok || rc.Enabled == nil {
+		return true
+	}
+	return *rc.Enabled
+}
+
+// overrideSeverity sets the severity of a rule, replacing any severity from the
+// configuration file.
+func (cfg *config) overrideSeverity(id string, s lint.Severity) {
+	if cfg.rules == nil {
+		cfg.rules = map[string]ruleConfig{}
+	}
+	rc := cfg.rules[id]
+	rc.ID = id
+	rc.severity = s
+	cfg.rules[id] = rc
+}
+
+// ruleSeverity returns the severity of an issue found by the rule. Configured
+// severities take precedence over the issue's own severity, which in turn takes
+// precedence over the default severity of the rule.
+func (cfg *config) ruleSeverity(id string, issueSeverity lint.Severity) lint.Severity {
+	if rc, ok := cfg.rules[id]; ok && rc.severity != lint.SeverityDefault {
+		return rc.severity
+	}
+	if issueSeverity != lint.SeverityDefault {
+		return issueSeverity
+	}
+	if info, ok := ruleInfos[id]; ok && info.Severity != lint.SeverityDefault {
+		return info.Severity
+	}
+	if info, ok := cfg.customRules[id]; ok && info.Severity != lint.SeverityDefault {
+		return info.Severity
+	}
+	return lint.SeverityError
+}
+
+func (cfg *config) resourceExcluded(readOnly bool, name string) bool {
+	if readOnly {
+		name = "data." + name
+	}
+	for _, pattern := range cfg.ExcludeResources {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *config) fileExcluded(filename string) bool {
+	if len(cfg.ExcludeFiles) == 0 {
+		return false
+	}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(cfg.dir, abs)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range cfg.ExcludeFiles {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *config) filterResources(readOnly bool, resources []provparse.Resource) []provparse.Resource {
+	if len(cfg.ExcludeResources) == 0 {
+		return resources
+	}
+
+	filtered := make([]provparse.Resource, 0, len(resources))
+	for _, r := range resources {
+		if !cfg.resourceExcluded(readOnly, r.Name) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// applyToResults removes results in excluded files and sets the configured
+// severity of the remaining results.
+func (cfg *config) applyToResults(fset *token.FileSet, results []issueResult) []issueResult {
+	applied := make([]issueResult, 0, len(results))
+	for _, res := range results {
+		if cfg.fileExcluded(fset.Position(res.Issue.Pos).Filename) {
+			continue
+		}
+		res.Severity = cfg.ruleSeverity(res.RuleID, res.Issue.Severity)
+		applied = append(applied, res)
+	}
+	return applied
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+const testConfig = `
+exclude_resources = ["aws_instance", "data.aws_ami*"]
+exclude_files     = ["*_migrate.go", "aws/internal/*.go"]
+
+rule "tfprovlint002" {
+  enabled = false
+}
+
+rule "tfprovlint029" {
+  severity = "warning"
+}
+
+call_blacklist "acme001" {
+  description = "Do not sleep in resource functions"
+  severity    = "warning"
+  all         = ["time.Sleep"]
+  read        = ["net/http.Get"]
+}
+
+call_blacklist "acme002" {
+  message = "use the API client instead of %s"
+  create  = ["(*net/http.Client).Do"]
+}
+
+call_required "acme003" {
+  description = "Resources must be tagged"
+  create      = ["github.com/acme/terraform-provider-acme/acme.setTags"]
+  update      = ["github.com/acme/terraform-provider-acme/acme.setTags"]
+}
+
+plugin "tags" {
+  command = "./bin/tags-plugin"
+  args    = ["-strict"]
+}
+
+plugin "naming" {
+  command = "naming-plugin"
+}
+`
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := parseConfig(testConfig, "/src/provider")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("rules", func(t *testing.T) {
+		if cfg.ruleEnabled("tfprovlint002") {
+			t.Fatal("expected tfprovlint002 to be disabled")
+		}
+		if !cfg.ruleEnabled("tfprovlint029") {
+			t.Fatal("expected tfprovlint029 to be enabled")
+		}
+		if !cfg.ruleEnabled("tfprovlint001") {
+			t.Fatal("expected unconfigured rules to be enabled")
+		}
+	})
+
+	t.Run("severity", func(t *testing.T) {
+		for _, c := range []struct {
+			expected lint.Severity
+			id       string
+			issue    lint.Severity
+		}{
+			{lint.SeverityWarning, "tfprovlint029", lint.SeverityDefault},
+			{lint.SeverityWarning, "tfprovlint029", lint.SeverityInfo},
+			{lint.SeverityError, "tfprovlint001", lint.SeverityDefault},
+			{lint.SeverityInfo, "tfprovlint001", lint.SeverityInfo},
+			{lint.SeverityWarning, "tfprovlint002", lint.SeverityDefault},
+		} {
+			if actual := cfg.ruleSeverity(c.id, c.issue); actual != c.expected {
+				t.Errorf("expected %s for %s (issue severity %s), got %s", c.expected, c.id, c.issue, actual)
+			}
+		}
+	})
+
+	t.Run("override severity", func(t *testing.T) {
+		cfg, err := parseConfig(testConfig, "/src/provider")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.overrideSeverity("tfprovlint029", lint.SeverityInfo)
+		cfg.overrideSeverity("tfprovlint002", lint.SeverityError)
+		if s := cfg.ruleSeverity("tfprovlint029", lint.SeverityDefault); s != lint.SeverityInfo {
+			t.Fatalf("unexpected severity %s for tfprovlint029", s)
+		}
+		if s := cfg.ruleSeverity("tfprovlint002", lint.SeverityDefault); s != lint.SeverityError {
+			t.Fatalf("unexpected severity %s for tfprovlint002", s)
+		}
+		if cfg.ruleEnabled("tfprovlint002") {
+			t.Fatal("expected overriding the severity to leave tfprovlint002 disabled")
+		}
+	})
+
+	t.Run("resources", func(t *testing.T) {
+		for _, c := range []struct {
+			expected bool
+			readOnly bool
+			name     string
+		}{
+			{true, false, "aws_instance"},
+			{false, true, "aws_instance"},
+			{true, true, "aws_ami"},
+			{true, true, "aws_ami_ids"},
+			{false, false, "aws_ami"},
+		} {
+			if actual := cfg.resourceExcluded(c.readOnly, c.name); actual != c.expected {
+				t.Errorf("expected %t for %q (read only %t)", c.expected, c.name, c.readOnly)
+			}
+		}
+	})
+
+	t.Run("call blacklists", func(t *testing.T) {
+		factories := cfg.resourceRules()
+		for _, id := range []string{"tfprovlint001", "acme001", "acme002"} {
+			if factories[id] == nil {
+				t.Fatalf("expected a rule for %s", id)
+			}
+		}
+		if s := cfg.ruleSeverity("acme001", lint.SeverityDefault); s != lint.SeverityWarning {
+			t.Fatalf("unexpected severity %s for acme001", s)
+		}
+		if s := cfg.ruleSeverity("acme002", lint.SeverityDefault); s != lint.SeverityError {
+			t.Fatalf("unexpected severity %s for acme002", s)
+		}
+
+		bc := cfg.CallBlacklists[0]
+		calls := bc.callList()
+		if len(calls.Read) != 2 || len(calls.Delete) != 1 {
+			t.Fatalf("expected all to apply to every resource function: %#v", calls)
+		}
+		if bc.Message != defaultCallBlacklistMessage {
+			t.Fatalf("unexpected default message %q", bc.Message)
+		}
+	})
+
+	t.Run("call required", func(t *testing.T) {
+		if factories := cfg.resourceRules(); factories["acme003"] == nil {
+			t.Fatal("expected a rule for acme003")
+		}
+		if info := cfg.customRules["acme003"]; info.Description != "Resources must be tagged" || info.Category != categoryRuntime {
+			t.Fatalf("unexpected rule info %#v", info)
+		}
+
+		rc := cfg.CallRequired[0]
+		if calls := rc.callList(); len(calls.Create) != 1 || len(calls.Update) != 1 || len(calls.Read) != 0 {
+			t.Fatalf("unexpected calls %#v", calls)
+		}
+		if rc.Message != defaultCallRequiredMessage {
+			t.Fatalf("unexpected default message %q", rc.Message)
+		}
+	})
+
+	t.Run("plugins", func(t *testing.T) {
+		clients := cfg.pluginClients()
+		if len(clients) != 2 {
+			t.Fatalf("expected 2 plugins, found %d", len(clients))
+		}
+		if c := clients[0]; c.Command != filepath.FromSlash("/src/provider/bin/tags-plugin") || len(c.Args) != 1 || c.Args[0] != "-strict" {
+			t.Fatalf("unexpected plugin %#v", c)
+		}
+		if c := clients[1]; c.Command != "naming-plugin" {
+			t.Fatalf("expected naming-plugin to be looked up in PATH, found %q", c.Command)
+		}
+	})
+
+	t.Run("files", func(t *testing.T) {
+		for _, c := range []struct {
+			expected bool
+			filename string
+		}{
+			{true, "/src/provider/aws/resource_aws_instance_migrate.go"},
+			{true, "/src/provider/aws/internal/foo.go"},
+			{false, "/src/provider/aws/resource_aws_instance.go"},
+			{false, "/src/provider/internal/foo.go"},
+		} {
+			if actual := cfg.fileExcluded(c.filename); actual != c.expected {
+				t.Errorf("expected %t for %q", c.expected, c.filename)
+			}
+		}
+	})
+}
+
+func TestParseConfig_invalid(t *testing.T) {
+	for _, src := range []string{
+		`rule "tfprovlint001" { severity = "fatal" }`,
+		`rule "tfprovlint001" {} rule "tfprovlint001" {}`,
+		`exclude_files = ["[a-"]`,
+		`rule "tfprovlint001" {`,
+		`plugin "tags" {}`,
+		`call_blacklist "tfprovlint001" { all = ["time.Sleep"] }`,
+		`call_blacklist "acme001" {}`,
+		`call_blacklist "acme001" { message = "no %d", all = ["time.Sleep"] }`,
+		`call_blacklist "acme001" { message = "no %s %s", all = ["time.Sleep"] }`,
+		`call_blacklist "acme001" { severity = "fatal", all = ["time.Sleep"] }`,
+		`call_blacklist "acme001" { all = ["time.Sleep"] } call_blacklist "acme001" { all = ["time.Sleep"] }`,
+		`call_required "tfprovlint001" { create = ["time.Sleep"] }`,
+		`call_required "acme001" { message = "missing", create = ["time.Sleep"] }`,
+		`call_blacklist "acme001" { all = ["time.Sleep"] } call_required "acme001" { create = ["time.Sleep"] }`,
+	} {
+		if _, err := parseConfig(src, "/"); err == nil {
+			t.Errorf("expected error for %q", src)
+		}
+	}
+}
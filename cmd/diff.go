@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+type diffLine struct {
+	// kind is ' ' for unchanged lines, '-' for deletions and '+' for insertions.
+	kind byte
+	text string
+}
+
+// unifiedDiff returns a unified diff of the changes from a to b, or an empty
+// string if they are the same.
+func unifiedDiff(name string, a, b []byte) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	// aLines[i] and bLines[i] are the number of lines of a and b before lines[i]
+	aLines := make([]int, len(lines)+1)
+	bLines := make([]int, len(lines)+1)
+	for i, l := range lines {
+		aLines[i+1], bLines[i+1] = aLines[i], bLines[i]
+		if l.kind != '+' {
+			aLines[i+1]++
+		}
+		if l.kind != '-' {
+			bLines[i+1]++
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// extend the hunk while the next change is close enough to share context
+		last := i
+		for j := i + 1; j < len(lines) && j <= last+2*diffContext; j++ {
+			if lines[j].kind != ' ' {
+				last = j
+			}
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := last + diffContext + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		if buf.Len() == 0 {
+			fmt.Fprintf(buf, "--- %s.orig\n+++ %s\n", name, name)
+		}
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n",
+			hunkRange(aLines[start], aLines[end]-aLines[start]),
+			hunkRange(bLines[start], bLines[end]-bLines[start]))
+		for _, l := range lines[start:end] {
+			buf.WriteByte(l.kind)
+			buf.WriteString(l.text)
+			if !strings.HasSuffix(l.text, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = end
+	}
+
+	return buf.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits src after each newline.
+func splitLines(src []byte) []string {
+	s := string(src)
+	var lines []string
+	for s != "" {
+		i := strings.IndexByte(s, '\n') + 1
+		if i == 0 {
+			i = len(s)
+		}
+		lines = append(lines, s[:i])
+		s = s[i:]
+	}
+	return lines
+}
+
+// diffLines returns the lines of a and b marked as unchanged, deleted, or
+// inserted. It uses the linear space variant of Myers' algorithm, so memory
+// only grows with the size of the inputs and not with the number of changes.
+func diffLines(a, b []string) []diffLine {
+	return appendDiffLines(make([]diffLine, 0, len(a)+len(b)), a, b)
+}
+
+func appendDiffLines(lines []diffLine, a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, l := range a[:prefix] {
+		lines = append(lines, diffLine{' ', l})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	switch {
+	case len(ma) == 0:
+		for _, l := range mb {
+			lines = append(lines, diffLine{'+', l})
+		}
+	case len(mb) == 0:
+		for _, l := range ma {
+			lines = append(lines, diffLine{'-', l})
+		}
+	default:
+		// with the common lines trimmed there are at least two edits, so both
+		// sides of the middle snake are smaller than the whole
+		x0, y0, x1, y1 := middleSnake(ma, mb)
+		lines = appendDiffLines(lines, ma[:x0], mb[:y0])
+		for _, l := range ma[x0:x1] {
+			lines = append(lines, diffLine{' ', l})
+		}
+		lines = appendDiffLines(lines, ma[x1:], mb[y1:])
+	}
+
+	for _, l := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	return lines
+}
+
+// middleSnake returns the start and end of the snake (a run of common lines)
+// in the middle of a shortest edit script from a to b, found by searching
+// forward from the start and backward from the end until the paths overlap.
+func middleSnake(a, b []string) (x0, y0, x1, y1 int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	// forward[k] is the furthest x reached from the start on diagonal k = x - y,
+	// backward[k] the furthest x reached from the end in reversed coordinates
+	forward := make([]int, 2*maxD+3)
+	backward := make([]int, 2*maxD+3)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if r := delta - k; delta%2 != 0 && r >= -(d-1) && r <= d-1 && x+backward[offset+r] >= n {
+				return sx, sy, x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+			if f := delta - k; delta%2 == 0 && f >= -d && f <= d && x+forward[offset+f] >= n {
+				return n - x, m - y, n - sx, m - sy
+			}
+		}
+	}
+
+	// unreachable, the paths always overlap by the time d reaches maxD
+	return 0, 0, n, m
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		expected string
+		a        string
+		b        string
+	}{
+		{"same", "", "a\nb\n", "a\nb\n"},
+		{"change", `--- f.go.orig
++++ f.go
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++x
+ 4
+ 5
+ 6
+`, "1\n2\n3\n4\n5\n6\n7\n8\n9\n", "1\n2\nx\n4\n5\n6\n7\n8\n9\n"},
+		{"delete", `--- f.go.orig
++++ f.go
+@@ -4,4 +4,3 @@
+ 4
+ 5
+ 6
+-7
+`, "1\n2\n3\n4\n5\n6\n7\n", "1\n2\n3\n4\n5\n6\n"},
+		{"separate hunks", `--- f.go.orig
++++ f.go
+@@ -1,4 +1,4 @@
+-1
++x
+ 2
+ 3
+ 4
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++y
+`, "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n", "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n"},
+		{"insert", `--- f.go.orig
++++ f.go
+@@ -1,2 +1,3 @@
+ 1
++x
+ 2
+`, "1\n2\n", "1\nx\n2\n"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			actual := unifiedDiff("f.go", []byte(c.a), []byte(c.b))
+			if actual != c.expected {
+				t.Fatalf("unexpected diff:\n%s", actual)
+			}
+		})
+	}
+}
+
+func TestDiffLines_minimal(t *testing.T) {
+	// lcsLength is the quadratic reference implementation
+	lcsLength := func(a, b []string) int {
+		lcs := make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				switch {
+				case a[i] == b[j]:
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				case lcs[i+1][j] >= lcs[i][j+1]:
+					lcs[i][j] = lcs[i+1][j]
+				default:
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+		return lcs[0][0]
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(12))
+		for i := range lines {
+			lines[i] = string('a' + rune(rnd.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, b := randomLines(), randomLines()
+		var actualA, actualB []string
+		common := 0
+		for _, l := range diffLines(a, b) {
+			if l.kind != '+' {
+				actualA = append(actualA, l.text)
+			}
+			if l.kind != '-' {
+				actualB = append(actualB, l.text)
+			}
+			if l.kind == ' ' {
+				common++
+			}
+		}
+		if strings.Join(actualA, "") != strings.Join(a, "") || strings.Join(actualB, "") != strings.Join(b, "") {
+			t.Fatalf("diff of %q and %q does not reproduce the inputs", a, b)
+		}
+		if expected := lcsLength(a, b); common != expected {
+			t.Fatalf("diff of %q and %q has %d common lines, expected %d", a, b, common, expected)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+// Exit codes returned by the commands.
+const (
+	// exitCodeOK indicates the command ran successfully and no issues
+	// exceeded the failure threshold.
+	exitCodeOK = 0
+	// exitCodeIssuesFound indicates the command ran successfully but issues
+	// were found that exceeded the failure threshold.
+	exitCodeIssuesFound = 1
+	// exitCodeError indicates the tool was unable to complete, for example
+	// because of invalid arguments, a failure parsing the provider, or a rule
+	// failing with an internal error.
+	exitCodeError = 2
+)
+
+const failOnNone = "none"
+
+// failThreshold determines if the results of a lint run should fail the
+// command.
+type failThreshold struct {
+	// count is the minimum number of issues required to fail, zero means the
+	// command never fails due to issues.
+	count int
+	// severity is the minimum severity an issue must have to be counted.
+	severity lint.Severity
+}
+
+// parseFailThreshold parses a -fail-on value, which is either an issue count,
+// a minimum severity (in which case a single issue fails), or "none".
+func parseFailThreshold(v string) (failThreshold, error) {
+	if v == failOnNone {
+		return failThreshold{}, nil
+	}
+
+	if s, err := lint.ParseSeverity(v); err == nil {
+		return failThreshold{
+			count:    1,
+			severity: s,
+		}, nil
+	}
+
+	count, err := strconv.Atoi(v)
+	if err != nil || count < 1 {
+		return failThreshold{}, fmt.Errorf("invalid -fail-on value %q, expected a positive issue count, a severity (error, warning, or info), or %q", v, failOnNone)
+	}
+
+	return failThreshold{
+		count: count,
+	}, nil
+}
+
+func (t failThreshold) exceeded(results []issueResult) bool {
+	if t.count == 0 {
+		return false
+	}
+	count := 0
+	for _, res := range results {
+		if res.Severity >= t.severity {
+			count++
+		}
+	}
+	return count >= t.count
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+func TestFailThreshold(t *testing.T) {
+	results := []issueResult{
+		{RuleID: "tfprovlint029", Severity: lint.SeverityWarning},
+		{RuleID: "tfprovlint029", Severity: lint.SeverityWarning},
+		{RuleID: "tfprovlint002", Severity: lint.SeverityInfo},
+	}
+
+	for _, c := range []struct {
+		expected bool
+		failOn   string
+	}{
+		{true, "1"},
+		{true, "3"},
+		{false, "4"},
+		{false, "none"},
+		{false, "error"},
+		{true, "warning"},
+		{true, "info"},
+	} {
+		t.Run(c.failOn, func(t *testing.T) {
+			threshold, err := parseFailThreshold(c.failOn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := threshold.exceeded(results); actual != c.expected {
+				t.Fatalf("expected %t, got %t", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseFailThreshold_invalid(t *testing.T) {
+	for _, v := range []string{"", "0", "-1", "default", "errors"} {
+		if _, err := parseFailThreshold(v); err == nil {
+			t.Errorf("expected error for %q", v)
+		}
+	}
+}
+
+func TestLintCommand_exitCode(t *testing.T) {
+	threshold, err := parseFailThreshold("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range []struct {
+		expected int
+		results  []issueResult
+	}{
+		{exitCodeOK, nil},
+		{exitCodeOK, []issueResult{{Severity: lint.SeverityWarning}}},
+		{exitCodeIssuesFound, []issueResult{{Severity: lint.SeverityWarning}, {Severity: lint.SeverityError}}},
+		{exitCodeError, []issueResult{{Severity: lint.SeverityError, Internal: true}}},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ui := cli.NewMockUi()
+			actual := (&lintCommand{UI: ui}).exitCode(threshold, c.results)
+			if actual != c.expected {
+				t.Fatalf("expected %d, got %d", c.expected, actual)
+			}
+		})
+	}
+}
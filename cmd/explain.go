@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type explainCommand struct {
+	UI cli.Ui
+}
+
+func (c *explainCommand) Help() string {
+	return strings.TrimSpace(`
+Usage: tfprovlint explain <rule-id>
+
+  Explains a rule, why it exists, and examples of the issues it finds.
+`)
+}
+
+func (c *explainCommand) Synopsis() string {
+	return "Explains a rule"
+}
+
+func (c *explainCommand) Run(args []string) int {
+	flags := flag.NewFlagSet("explain", flag.ContinueOnError)
+	err := flags.Parse(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return exitCodeError
+	}
+
+	if flags.NArg() != 1 {
+		c.UI.Error("you must specify a single rule ID to explain")
+		return exitCodeError
+	}
+
+	id := flags.Arg(0)
+	info, ok := ruleInfos[id]
+	if !ok {
+		c.UI.Error(fmt.Sprintf("unknown rule %q, run `tfprovlint rules` for a list of rules", id))
+		return exitCodeError
+	}
+
+	buf := &bytes.Buffer{}
+	writeExplanation(buf, id, info)
+	c.UI.Output(strings.TrimSuffix(buf.String(), "\n"))
+
+	return exitCodeOK
+}
+
+func writeExplanation(w io.Writer, id string, info ruleInfo) {
+	falsePositives := "no"
+	if info.FalsePositives {
+		falsePositives = "yes"
+	}
+
+	fmt.Fprintf(w, "%s: %s\n\n", id, info.Description)
+	fmt.Fprintf(w, "Category:         %s\n", info.Category)
+	fmt.Fprintf(w, "Default severity: %s\n", info.Severity)
+	fmt.Fprintf(w, "False positives:  %s\n", falsePositives)
+
+	if info.Doc.Rationale != "" {
+		fmt.Fprintf(w, "\n%s\n", info.Doc.Rationale)
+	}
+
+	for _, section := range []struct {
+		title string
+		text  string
+		code  bool
+	}{
+		{"Bad", info.Doc.Bad, true},
+		{"Good", info.Doc.Good, true},
+		{"Known false positives", info.Doc.FalsePositives, false},
+	} {
+		if section.text == "" {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n\n", section.title)
+		indent := ""
+		if section.code {
+			indent = "    "
+		}
+		for _, line := range strings.Split(section.text, "\n") {
+			fmt.Fprintln(w, strings.TrimRight(indent+line, " "))
+		}
+	}
+}
+
+func ExplainCommandFactory(ui cli.Ui) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return &explainCommand{
+			UI: ui,
+		}, nil
+	}
+}
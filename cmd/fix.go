@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/imports"
+)
+
+// sourceEdit is a lint.TextEdit resolved to byte offsets in a file.
+type sourceEdit struct {
+	start   int
+	end     int
+	newText string
+}
+
+func (e sourceEdit) overlaps(other sourceEdit) bool {
+	if e.start == e.end && other.start == other.end {
+		// two insertions at the same offset would be ambiguous
+		return e.start == other.start
+	}
+	return e.start < other.end && other.start < e.end
+}
+
+// expandLineDeletion expands a deletion to the entire line if nothing else is
+// left on it, so removing a statement does not leave a blank line.
+func (e sourceEdit) expandLineDeletion(src []byte) sourceEdit {
+	if e.newText != "" {
+		return e
+	}
+	start := e.start
+	for start > 0 && (src[start-1] == ' ' || src[start-1] == '\t') {
+		start--
+	}
+	end := e.end
+	for end < len(src) && (src[end] == ' ' || src[end] == '\t') {
+		end++
+	}
+	if (start > 0 && src[start-1] != '\n') || (end < len(src) && src[end] != '\n') {
+		return e
+	}
+	if end < len(src) {
+		end++
+	}
+	return sourceEdit{start, end, ""}
+}
+
+// fixPlan is the set of suggested fixes to apply, grouped by file.
+type fixPlan struct {
+	edits map[string][]sourceEdit
+	// fixed are the indexes of the results whose fix is in the plan.
+	fixed map[int]bool
+}
+
+// planFixes selects the first suggested fix of each result. Fixes that overlap
+// a fix already selected are skipped, unless they are identical, which happens
+// when the same code is reached from more than one resource function.
+func planFixes(fset *token.FileSet, results []issueResult) *fixPlan {
+	plan := &fixPlan{
+		edits: map[string][]sourceEdit{},
+		fixed: map[int]bool{},
+	}
+
+results:
+	for i, res := range results {
+		if len(res.Issue.Fixes) == 0 {
+			continue
+		}
+
+		var filename string
+		var newEdits []sourceEdit
+		for _, e := range res.Issue.Fixes[0].Edits {
+			start, end := fset.Position(e.Pos), fset.Position(e.End)
+			if filename == "" {
+				filename = start.Filename
+			}
+			if start.Filename != filename || end.Filename != filename || end.Offset < start.Offset {
+				continue results
+			}
+			edit := sourceEdit{start.Offset, end.Offset, string(e.NewText)}
+			for _, existing := range plan.edits[filename] {
+				if existing == edit {
+					edit.start = -1
+					break
+				}
+				if existing.overlaps(edit) {
+					continue results
+				}
+			}
+			if edit.start >= 0 {
+				newEdits = append(newEdits, edit)
+			}
+		}
+
+		plan.edits[filename] = append(plan.edits[filename], newEdits...)
+		plan.fixed[i] = true
+	}
+
+	return plan
+}
+
+// fixedFile is the original and fixed source of a file.
+type fixedFile struct {
+	Filename string
+	Original []byte
+	Fixed    []byte
+}
+
+// apply reads the files in the plan and returns their fixed source, sorted by
+// file name. Imports of the fixed files are updated and the files are
+// formatted.
+func (plan *fixPlan) apply() ([]fixedFile, error) {
+	filenames := make([]string, 0, len(plan.edits))
+	for filename := range plan.edits {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	files := make([]fixedFile, 0, len(filenames))
+	for _, filename := range filenames {
+		src, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		edits := plan.edits[filename]
+		for i, e := range edits {
+			if e.end > len(src) {
+				return nil, fmt.Errorf("suggested fix is outside of %s, has the file changed?", filename)
+			}
+			edits[i] = e.expandLineDeletion(src)
+		}
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].start > edits[j].start
+		})
+		fixed := append([]byte(nil), src...)
+		for _, e := range edits {
+			fixed = append(fixed[:e.start:e.start], append([]byte(e.newText), fixed[e.end:]...)...)
+		}
+
+		fixed, err = imports.Process(filename, fixed, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error applying fixes to %s: %s", filename, err)
+		}
+
+		files = append(files, fixedFile{
+			Filename: filename,
+			Original: src,
+			Fixed:    fixed,
+		})
+	}
+
+	return files, nil
+}
+
+func writeFilePreserveMode(filename string, src []byte) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, src, info.Mode())
+}
@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
-	"log"
+	"go/token"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 
-	"github.com/fatih/color"
 	"github.com/kisielk/gotool"
 	"github.com/mitchellh/cli"
 
 	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/plugin"
 	"github.com/paultyng/tfprovlint/provparse"
 )
 
@@ -41,17 +46,54 @@ func (c *lintCommand) Run(args []string) int {
 	var dataSourceNames stringSliceFlags
 	var includeRules stringSliceFlags
 	var excludeRules stringSliceFlags
+	var severities stringSliceFlags
+	var pluginCommands stringSliceFlags
+	var format string
+	var failOn string
+	var configPath string
+	var baselinePath string
+	var writeBaselinePath string
+	var fix bool
+	var showDiff bool
+	var parallelism int
 
 	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.StringVar(&format, "format", "text", "output format (text, json, sarif, checkstyle, junit, or github)")
+	flags.StringVar(&failOn, "fail-on", "1", "minimum number of issues, or minimum issue severity, to exit with a failure status, or \"none\"")
+	flags.StringVar(&configPath, "config", "", "path to the configuration file, by default "+configFileName+" is searched for from the provider directory")
+	flags.StringVar(&baselinePath, "baseline", "", "path to a baseline file of known issues to ignore")
+	flags.StringVar(&writeBaselinePath, "write-baseline", "", "write the issues found to a baseline file instead of reporting them")
+	flags.BoolVar(&fix, "fix", false, "apply suggested fixes to the source files and report the remaining issues")
+	flags.BoolVar(&showDiff, "diff", false, "output a diff of the suggested fixes instead of reporting issues")
+	flags.IntVar(&parallelism, "parallelism", runtime.NumCPU(), "number of resources to lint concurrently")
 	flags.Var(&includeRules, "include", "list of rules to include")
 	flags.Var(&excludeRules, "exclude", "list of rules to exclude")
+	flags.Var(&pluginCommands, "plugin", "list of plugin executables to load rules from, in addition to configured plugins")
+	flags.Var(&severities, "severity", "list of rule severity overrides in the form id=severity")
 	flags.Var(&resourceNames, "rs", "list of resources to lint")
 	flags.Var(&dataSourceNames, "ds", "list of data sources to lint")
 
 	err := flags.Parse(args)
 	if err != nil {
 		c.UI.Error(err.Error())
-		return -1
+		return exitCodeError
+	}
+
+	if parallelism < 1 {
+		c.UI.Error(fmt.Sprintf("invalid -parallelism %d, must be at least 1", parallelism))
+		return exitCodeError
+	}
+
+	threshold, err := parseFailThreshold(failOn)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return exitCodeError
+	}
+
+	formatter, ok := reportFormatters[format]
+	if !ok {
+		c.UI.Error(fmt.Sprintf("unknown output format %q", format))
+		return exitCodeError
 	}
 
 	filtered := len(resourceNames) > 0 || len(dataSourceNames) > 0
@@ -59,52 +101,219 @@ func (c *lintCommand) Run(args []string) int {
 	prov, err := parseProvider(flags.Args())
 	if err != nil {
 		c.UI.Error(err.Error())
-		return -1
+		return exitCodeError
 	}
 
-	rules := loadRules(includeRules, excludeRules)
-	results := []issueResult{}
+	cfg, err := loadConfig(configPath, prov)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return exitCodeError
+	}
 
-	dataSources := prov.DataSources
-	if filtered {
-		dataSources = filterResources(dataSources, dataSourceNames)
+	clients := cfg.pluginClients()
+	for _, command := range pluginCommands {
+		clients = append(clients, &plugin.Client{Command: command})
 	}
-	newResults, err := evaluateRules(true, rules, dataSources)
+	known := knownRuleIDs()
+	customRules := map[string]ruleInfo{}
+	for id, info := range cfg.customRules {
+		known[id] = true
+		customRules[id] = info
+	}
+	plugins, err := loadPlugins(known, clients)
 	if err != nil {
 		c.UI.Error(err.Error())
-		return -1
+		return exitCodeError
 	}
-	results = append(results, newResults...)
-
-	resources := prov.Resources
-	if filtered {
-		resources = filterResources(resources, resourceNames)
+	for id, info := range pluginRuleInfos(plugins) {
+		known[id] = true
+		customRules[id] = info
 	}
-	newResults, err = evaluateRules(false, rules, resources)
+
+	err = cfg.validateRuleIDs(known)
 	if err != nil {
 		c.UI.Error(err.Error())
-		return -1
+		return exitCodeError
 	}
-	results = append(results, newResults...)
 
-	c.UI.Output("")
-	for _, res := range results {
-		prefix := ""
-		if res.ReadOnly {
-			prefix = "data."
+	for _, v := range severities {
+		id, s, err := parseSeverityOverride(known, v)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return exitCodeError
+		}
+		cfg.overrideSeverity(id, s)
+	}
+
+	rules := loadRules(known, cfg, includeRules, excludeRules)
+	factories := cfg.resourceRules()
+
+	var b baseline
+	if baselinePath != "" && writeBaselinePath == "" {
+		b, err = readBaseline(baselinePath)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return exitCodeError
 		}
+	}
+
+	// lintProvider evaluates the rules and applies the suppressions,
+	// configuration and baseline to the results. It is run again after fixes
+	// are written so the remaining issues are reported at their new positions.
+	lintProvider := func(prov *provparse.Provider) (*lintReport, suppressions) {
+		results := []issueResult{}
+
+		dataSources := cfg.filterResources(true, prov.DataSources)
+		if filtered {
+			dataSources = filterResources(dataSources, dataSourceNames)
+		}
+		results = append(results, evaluateRules(true, rules, factories, dataSources, parallelism)...)
+
+		resources := cfg.filterResources(false, prov.Resources)
+		if filtered {
+			resources = filterResources(resources, resourceNames)
+		}
+		results = append(results, evaluateRules(false, rules, factories, resources, parallelism)...)
+
+		// provider rules only see the resources and data sources being linted
+		filteredProv := *prov
+		filteredProv.DataSources = dataSources
+		filteredProv.Resources = resources
+		results = append(results, evaluateProviderRules(rules, &filteredProv)...)
+
+		results = append(results, evaluatePlugins(plugins, rules, &filteredProv)...)
+
+		sortResults(prov.Fset, results)
+
+		suppressed := parseSuppressions(prov.Fset, prov.Files)
+		results = suppressed.apply(prov.Fset, results)
+
+		results = cfg.applyToResults(prov.Fset, results)
+		if b != nil {
+			results = b.filter(results)
+		}
+
+		return &lintReport{
+			Provider:    prov,
+			Rules:       rules,
+			CustomRules: customRules,
+			DataSources: dataSources,
+			Resources:   resources,
+			Results:     results,
+		}, suppressed
+	}
+
+	warnUnused := func(report *lintReport, suppressed suppressions) {
+		if filtered || len(cfg.ExcludeResources) > 0 {
+			// only report unused suppressions if all resources were linted
+			return
+		}
+		// issues in excluded files are dropped after the suppressions are applied
+		for _, msg := range suppressed.excludeFiles(cfg.fileExcluded).unused(report.Provider.Fset, rules) {
+			c.UI.Warn(msg)
+		}
+	}
+
+	report, suppressed := lintProvider(prov)
+	if !fix {
+		warnUnused(report, suppressed)
+	}
+
+	if writeBaselinePath != "" {
+		err = newBaseline(report.Results).write(writeBaselinePath)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return exitCodeError
+		}
+		c.UI.Output(fmt.Sprintf("%d issues written to baseline %s", len(report.Results), writeBaselinePath))
+		return exitCodeOK
+	}
+
+	if fix || showDiff {
+		plan := planFixes(prov.Fset, report.Results)
+		files, err := plan.apply()
+		if err != nil {
+			c.UI.Error(err.Error())
+			return exitCodeError
+		}
+
+		if showDiff {
+			remaining := make([]issueResult, 0, len(report.Results)-len(plan.fixed))
+			for i, res := range report.Results {
+				if !plan.fixed[i] {
+					remaining = append(remaining, res)
+				}
+			}
+
+			for _, f := range files {
+				if d := unifiedDiff(f.Filename, f.Original, f.Fixed); d != "" {
+					c.UI.Output(strings.TrimSuffix(d, "\n"))
+				}
+			}
+			return c.exitCode(threshold, remaining)
+		}
+
+		for _, f := range files {
+			err = writeFilePreserveMode(f.Filename, f.Fixed)
+			if err != nil {
+				c.UI.Error(err.Error())
+				return exitCodeError
+			}
+		}
+		// written to stderr so machine readable reports on stdout stay valid
+		c.UI.Warn(fmt.Sprintf("%d issues fixed in %d files", len(plan.fixed), len(files)))
+
+		if len(files) > 0 {
+			prov, err = parseProvider(flags.Args())
+			if err != nil {
+				c.UI.Error(err.Error())
+				return exitCodeError
+			}
+			report, suppressed = lintProvider(prov)
+		}
+		warnUnused(report, suppressed)
+	}
 
-		line := "[" + color.WhiteString("%s%s", prefix, res.Resource.Name) + "] " +
-			"[" + color.RedString("%s", res.RuleID) + "] " +
-			fmt.Sprintf("%s: ", prov.Fset.Position(res.Issue.Pos)) +
-			color.WhiteString("%s", res.Issue.Message)
+	buf := &bytes.Buffer{}
+	err = formatter(buf, report)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return exitCodeError
+	}
+	c.UI.Output(strings.TrimSuffix(buf.String(), "\n"))
+
+	return c.exitCode(threshold, report.Results)
+}
 
-		c.UI.Output(line)
+// exitCode returns the exit code for the results that were not fixed.
+func (c *lintCommand) exitCode(threshold failThreshold, results []issueResult) int {
+	if n := internalErrors(results); n > 0 {
+		c.UI.Error(fmt.Sprintf("%d rules failed with internal errors, the results are incomplete", n))
+		return exitCodeError
 	}
 
-	c.UI.Output(fmt.Sprintf("\n%d issues found", len(results)))
+	if threshold.exceeded(results) {
+		return exitCodeIssuesFound
+	}
 
-	return 0
+	return exitCodeOK
+}
+
+// parseSeverityOverride parses a -severity flag value in the form id=severity.
+func parseSeverityOverride(known map[string]bool, v string) (string, lint.Severity, error) {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 {
+		return "", lint.SeverityDefault, fmt.Errorf("invalid -severity value %q, expected id=severity", v)
+	}
+	id := parts[0]
+	if !known[id] {
+		return "", lint.SeverityDefault, fmt.Errorf("unknown rule %q in -severity", id)
+	}
+	s, err := lint.ParseSeverity(parts[1])
+	if err != nil {
+		return "", lint.SeverityDefault, fmt.Errorf("invalid -severity value %q: %s", v, err)
+	}
+	return id, s, nil
 }
 
 func LintCommandFactory(ui cli.Ui) cli.CommandFactory {
@@ -117,16 +326,43 @@ func LintCommandFactory(ui cli.Ui) cli.CommandFactory {
 
 type issueResult struct {
 	ReadOnly bool
-	Resource provparse.Resource
+	// Resource is nil for issues found by provider rules.
+	Resource *provparse.Resource
 	RuleID   string
+	Severity lint.Severity
 	Issue    lint.Issue
+	// Internal is true if the rule failed with an error or panic, the issue
+	// describes the failure.
+	Internal bool
+}
+
+// resourceName returns the name of the resource or data source, or an empty
+// string for provider issues.
+func (res issueResult) resourceName() string {
+	if res.Resource == nil {
+		return ""
+	}
+	return res.Resource.Name
+}
+
+// resourceLabel returns the resource name as it would be referenced in
+// configuration, data sources are prefixed with "data.". Provider issues are
+// labeled "provider".
+func (res issueResult) resourceLabel() string {
+	switch {
+	case res.Resource == nil:
+		return "provider"
+	case res.ReadOnly:
+		return "data." + res.Resource.Name
+	}
+	return res.Resource.Name
 }
 
 func parseProvider(paths []string) (*provparse.Provider, error) {
 	paths = gotool.ImportPaths(paths)
 
 	if len(paths) != 1 {
-		log.Println("you must specify only one import path to lint")
+		return nil, fmt.Errorf("you must specify only one import path to lint")
 	}
 
 	return provparse.Package(paths[0])
@@ -152,26 +388,157 @@ func filterResources(resources []provparse.Resource, resourceNames []string) []p
 	return filtered
 }
 
-func evaluateRules(readOnly bool, rules map[string]ruleFactoryFunc, resources []provparse.Resource) ([]issueResult, error) {
-	results := []issueResult{}
-	for _, r := range resources {
-		for id, factory := range rules {
-			rule := factory()
-			newIssues, err := rule.CheckResource(readOnly, &r)
-			if err != nil {
-				return nil, err
-			}
-			for _, iss := range newIssues {
-				results = append(results, issueResult{
-					ReadOnly: readOnly,
-					Issue:    iss,
-					Resource: r,
-					RuleID:   id,
-				})
+// evaluateRules checks the resources against the enabled rules, using up to
+// parallelism goroutines. Results are returned in resource, then rule ID, order.
+// Rules that fail are reported as internal errors.
+func evaluateRules(readOnly bool, rules map[string]bool, factories map[string]ruleFactoryFunc, resources []provparse.Resource, parallelism int) []issueResult {
+	ids := make([]string, 0, len(factories))
+	for id := range factories {
+		if rules[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	resourceResults := make([][]issueResult, len(resources))
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < parallelism && w < len(resources); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				resourceResults[i] = evaluateResource(readOnly, ids, factories, &resources[i])
 			}
+		}()
+	}
+	for i := range resources {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
+	results := []issueResult{}
+	for _, rr := range resourceResults {
+		results = append(results, rr...)
+	}
+
+	return results
+}
+
+func evaluateResource(readOnly bool, ids []string, factories map[string]ruleFactoryFunc, r *provparse.Resource) []issueResult {
+	results := []issueResult{}
+	for _, id := range ids {
+		newIssues, err := checkResource(factories[id], readOnly, r)
+		if err != nil {
+			// a failing rule is reported without stopping the other rules
+			results = append(results, internalErrorResult(readOnly, r, id, r.Pos(), err))
+			continue
+		}
+		for _, iss := range newIssues {
+			results = append(results, issueResult{
+				ReadOnly: readOnly,
+				Issue:    iss,
+				Resource: r,
+				RuleID:   id,
+			})
 		}
 	}
 
-	return results, nil
+	return results
+}
+
+func evaluateProviderRules(rules map[string]bool, prov *provparse.Provider) []issueResult {
+	results := []issueResult{}
+	for _, id := range sortedRuleIDs(rules) {
+		factory, ok := providerRules[id]
+		if !ok {
+			continue
+		}
+		newIssues, err := checkProvider(factory, prov)
+		if err != nil {
+			results = append(results, internalErrorResult(false, nil, id, prov.Pos(), err))
+			continue
+		}
+		for _, iss := range newIssues {
+			results = append(results, issueResult{
+				Issue:  iss,
+				RuleID: id,
+			})
+		}
+	}
+
+	return results
+}
+
+// checkResource checks the resource with a new instance of the rule, a panic is
+// returned as an error.
+func checkResource(factory ruleFactoryFunc, readOnly bool, r *provparse.Resource) (issues []lint.Issue, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			issues, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return factory().CheckResource(readOnly, r)
+}
+
+// checkProvider checks the provider with a new instance of the rule, a panic is
+// returned as an error.
+func checkProvider(factory providerRuleFactoryFunc, prov *provparse.Provider) (issues []lint.Issue, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			issues, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return factory().CheckProvider(prov)
+}
+
+// internalErrorResult records the failure of a rule as an error issue.
+func internalErrorResult(readOnly bool, r *provparse.Resource, id string, pos token.Pos, err error) issueResult {
+	res := issueResult{
+		ReadOnly: readOnly,
+		Resource: r,
+		RuleID:   id,
+		Internal: true,
+	}
+	res.Issue = lint.Issue{
+		Message:  fmt.Sprintf("internal error checking %s with %s: %s", res.resourceLabel(), id, err),
+		Pos:      pos,
+		Severity: lint.SeverityError,
+	}
+	return res
+}
+
+// internalErrors returns the number of results for rules that failed.
+func internalErrors(results []issueResult) int {
+	count := 0
+	for _, res := range results {
+		if res.Internal {
+			count++
+		}
+	}
+	return count
+}
+
+// sortResults sorts results by file, line, rule ID, and resource so the output
+// is the same on every run.
+func sortResults(fset *token.FileSet, results []issueResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		pi := fset.Position(results[i].Issue.Pos)
+		pj := fset.Position(results[j].Issue.Pos)
+		switch {
+		case pi.Filename != pj.Filename:
+			return pi.Filename < pj.Filename
+		case pi.Line != pj.Line:
+			return pi.Line < pj.Line
+		case results[i].RuleID != results[j].RuleID:
+			return results[i].RuleID < results[j].RuleID
+		case results[i].resourceLabel() != results[j].resourceLabel():
+			return results[i].resourceLabel() < results[j].resourceLabel()
+		case pi.Column != pj.Column:
+			return pi.Column < pj.Column
+		}
+		return results[i].Issue.Message < results[j].Issue.Message
+	})
 }
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/plugin"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+type nameRule struct {
+	id string
+}
+
+func (rule *nameRule) CheckResource(readOnly bool, r *provparse.Resource) ([]lint.Issue, error) {
+	return []lint.Issue{lint.NewIssuef(token.NoPos, "%s %s", r.Name, rule.id)}, nil
+}
+
+func TestEvaluateRules_order(t *testing.T) {
+	factories := map[string]ruleFactoryFunc{}
+	for _, id := range []string{"c", "a", "b", "disabled"} {
+		id := id
+		factories[id] = func() lint.ResourceRule {
+			return &nameRule{id}
+		}
+	}
+	rules := map[string]bool{"a": true, "b": true, "c": true}
+
+	resources := make([]provparse.Resource, 20)
+	expected := []string{}
+	for i := range resources {
+		resources[i].Name = fmt.Sprintf("r%02d", i)
+		for _, id := range []string{"a", "b", "c"} {
+			expected = append(expected, resources[i].Name+" "+id)
+		}
+	}
+
+	for _, parallelism := range []int{1, 4, 50} {
+		results := evaluateRules(false, rules, factories, resources, parallelism)
+		actual := make([]string, 0, len(results))
+		for _, res := range results {
+			actual = append(actual, res.Issue.Message)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("unexpected order with parallelism %d: %q", parallelism, actual)
+		}
+	}
+}
+
+type panicRule struct{}
+
+func (rule *panicRule) CheckResource(readOnly bool, r *provparse.Resource) ([]lint.Issue, error) {
+	if r.Name == "bad" {
+		var attrs []provparse.Attribute
+		_ = attrs[1]
+	}
+	return nil, nil
+}
+
+func TestEvaluateRules_internalError(t *testing.T) {
+	factories := map[string]ruleFactoryFunc{
+		"a": func() lint.ResourceRule { return &nameRule{"a"} },
+		"b": func() lint.ResourceRule { return &panicRule{} },
+	}
+	rules := map[string]bool{"a": true, "b": true}
+	resources := []provparse.Resource{{Name: "good"}, {Name: "bad"}, {Name: "other"}}
+
+	results := evaluateRules(true, rules, factories, resources, 2)
+
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, found %d", len(results))
+	}
+	if n := internalErrors(results); n != 1 {
+		t.Fatalf("expected 1 internal error, found %d", n)
+	}
+	res := results[2]
+	if !res.Internal || res.RuleID != "b" || res.resourceLabel() != "data.bad" {
+		t.Fatalf("unexpected internal error result %#v", res)
+	}
+	if msg := res.Issue.Message; !strings.HasPrefix(msg, "internal error checking data.bad with b: panic: ") {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	if results[3].Issue.Message != "other a" {
+		t.Fatalf("expected rules to continue after the panic, found %q", results[3].Issue.Message)
+	}
+	if b := newBaseline(results); len(b) != 3 {
+		t.Fatalf("expected internal errors not to be baselined: %v", b)
+	}
+}
+
+func TestEvaluatePlugins_failure(t *testing.T) {
+	plugins := []*loadedPlugin{
+		{
+			client: &plugin.Client{Command: "/nonexistent/tfprovlint-plugin"},
+			rules: map[string]plugin.Rule{
+				"p1":       {ID: "p1"},
+				"p2":       {ID: "p2"},
+				"disabled": {ID: "disabled"},
+			},
+		},
+	}
+	rules := map[string]bool{"p1": true, "p2": true}
+	prov := &provparse.Provider{Fset: token.NewFileSet()}
+
+	results := evaluatePlugins(plugins, rules, prov)
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, found %d", len(results))
+	}
+	if n := internalErrors(results); n != 2 {
+		t.Fatalf("expected 2 internal errors, found %d", n)
+	}
+	for i, id := range []string{"p1", "p2"} {
+		res := results[i]
+		if res.RuleID != id || res.Resource != nil {
+			t.Fatalf("unexpected internal error result %#v", res)
+		}
+		if msg := res.Issue.Message; !strings.HasPrefix(msg, "internal error checking provider with "+id+": plugin /nonexistent/tfprovlint-plugin: ") {
+			t.Fatalf("unexpected message %q", msg)
+		}
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	fset := token.NewFileSet()
+	a := fset.AddFile("a.go", -1, 100)
+	a.SetLines([]int{0, 10, 20})
+	b := fset.AddFile("b.go", -1, 100)
+	b.SetLines([]int{0, 10, 20})
+
+	result := func(pos token.Pos, resource, ruleID string) issueResult {
+		res := issueResult{
+			RuleID: ruleID,
+			Issue:  lint.Issue{Pos: pos},
+		}
+		if resource != "" {
+			res.Resource = &provparse.Resource{Name: resource}
+		}
+		return res
+	}
+
+	results := []issueResult{
+		result(b.Pos(0), "x", "tfprovlint001"),
+		result(a.Pos(15), "y", "tfprovlint002"),
+		result(a.Pos(12), "y", "tfprovlint001"),
+		result(a.Pos(11), "x", "tfprovlint002"),
+		result(a.Pos(1), "", "tfprovlint030"),
+	}
+	sortResults(fset, results)
+
+	expected := []string{
+		"a.go:1 provider tfprovlint030",
+		"a.go:2 y tfprovlint001",
+		"a.go:2 x tfprovlint002",
+		"a.go:2 y tfprovlint002",
+		"b.go:1 x tfprovlint001",
+	}
+	actual := make([]string, 0, len(results))
+	for _, res := range results {
+		pos := fset.Position(res.Issue.Pos)
+		actual = append(actual, fmt.Sprintf("%s:%d %s %s", pos.Filename, pos.Line, res.resourceLabel(), res.RuleID))
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("unexpected order %q", actual)
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// moduleRootMarkers are the files or directories that indicate the root of a
+// module or repository.
+var moduleRootMarkers = []string{"go.mod", ".git"}
+
+// moduleRoot walks up from dir to find the root of the module or repository
+// that contains it. An empty string is returned if no root is found.
+func moduleRoot(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, marker := range moduleRootMarkers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// moduleRelativePaths converts file names to paths relative to the root
+// of their module, caching the root lookups by directory.
+type moduleRelativePaths map[string]string
+
+func (roots moduleRelativePaths) rel(filename string) string {
+	rel, _, ok := roots.relRoot(filename)
+	if !ok {
+		return filename
+	}
+	return rel
+}
+
+// relRoot returns the slash separated path of filename relative to the root of
+// its module, and the root itself. False is returned if no root is found.
+func (roots moduleRelativePaths) relRoot(filename string) (string, string, bool) {
+	dir := filepath.Dir(filename)
+	root, ok := roots[dir]
+	if !ok {
+		root = moduleRoot(dir)
+		roots[dir] = root
+	}
+	if root == "" {
+		return "", "", false
+	}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return "", "", false
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", "", false
+	}
+	return filepath.ToSlash(rel), root, true
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/plugin"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// loadedPlugin is a plugin and the rules it implements.
+type loadedPlugin struct {
+	client *plugin.Client
+	rules  map[string]plugin.Rule
+}
+
+// loadPlugins starts each plugin to request its rules. Plugin rule IDs must not
+// conflict with known rules or the rules of other plugins.
+func loadPlugins(known map[string]bool, clients []*plugin.Client) ([]*loadedPlugin, error) {
+	known = copyRuleIDs(known)
+	plugins := make([]*loadedPlugin, 0, len(clients))
+	for _, c := range clients {
+		rules, err := c.Rules()
+		if err != nil {
+			return nil, err
+		}
+		p := &loadedPlugin{
+			client: c,
+			rules:  make(map[string]plugin.Rule, len(rules)),
+		}
+		for _, rule := range rules {
+			if rule.ID == "" {
+				return nil, fmt.Errorf("plugin %s: rule with no ID", c.Command)
+			}
+			if known[rule.ID] {
+				return nil, fmt.Errorf("plugin %s: rule %q is already registered", c.Command, rule.ID)
+			}
+			if rule.Severity != "" {
+				if _, err := lint.ParseSeverity(rule.Severity); err != nil {
+					return nil, fmt.Errorf("plugin %s: rule %q: %s", c.Command, rule.ID, err)
+				}
+			}
+			known[rule.ID] = true
+			p.rules[rule.ID] = rule
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// pluginRuleInfos returns the metadata of the rules of all plugins by ID.
+func pluginRuleInfos(plugins []*loadedPlugin) map[string]ruleInfo {
+	infos := map[string]ruleInfo{}
+	for _, p := range plugins {
+		for id, rule := range p.rules {
+			// the severity was validated when the plugin was loaded
+			severity, _ := lint.ParseSeverity(rule.Severity)
+			infos[id] = ruleInfo{
+				Description: rule.Description,
+				Category:    categoryPlugin,
+				Severity:    severity,
+			}
+		}
+	}
+	return infos
+}
+
+// evaluatePlugins checks the provider with each plugin that has enabled rules.
+// Issues in resources or data sources that are not being linted are dropped. A
+// plugin that fails is reported as an internal error for each of its enabled
+// rules.
+func evaluatePlugins(plugins []*loadedPlugin, rules map[string]bool, prov *provparse.Provider) []issueResult {
+	results := []issueResult{}
+	var serialized *plugin.Provider
+	for _, p := range plugins {
+		var enabled []string
+		for id := range p.rules {
+			if rules[id] {
+				enabled = append(enabled, id)
+			}
+		}
+		if len(enabled) == 0 {
+			continue
+		}
+		sort.Strings(enabled)
+
+		if serialized == nil {
+			serialized = plugin.NewProvider(prov)
+		}
+		issues, err := p.client.Check(serialized, enabled)
+		if err != nil {
+			for _, id := range enabled {
+				results = append(results, internalErrorResult(false, nil, id, prov.Pos(), err))
+			}
+			continue
+		}
+
+		for _, iss := range issues {
+			rule, ok := p.rules[iss.RuleID]
+			if !ok || !rules[iss.RuleID] {
+				continue
+			}
+
+			res := issueResult{
+				ReadOnly: iss.DataSource,
+				RuleID:   iss.RuleID,
+				Issue: lint.Issue{
+					Pos:           iss.Position.TokenPos(prov.Fset),
+					Message:       iss.Message,
+					AttributePath: iss.AttributePath,
+				},
+			}
+
+			if iss.Resource != "" {
+				if iss.DataSource {
+					res.Resource = prov.DataSource(iss.Resource)
+				} else {
+					res.Resource = prov.Resource(iss.Resource)
+				}
+				if res.Resource == nil {
+					continue
+				}
+			}
+
+			severity := iss.Severity
+			if severity == "" {
+				severity = rule.Severity
+			}
+			if severity != "" {
+				res.Issue.Severity, err = lint.ParseSeverity(severity)
+				if err != nil {
+					err = fmt.Errorf("plugin %s: %s", p.client.Command, err)
+					results = append(results, internalErrorResult(false, nil, iss.RuleID, prov.Pos(), err))
+					continue
+				}
+			}
+
+			results = append(results, res)
+		}
+	}
+
+	return results
+}
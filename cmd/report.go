@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+
+	"github.com/fatih/color"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// lintReport is everything a formatter needs to output the results of a lint run.
+type lintReport struct {
+	Provider *provparse.Provider
+	Rules    map[string]bool
+	// CustomRules are the rules defined by configuration or plugins.
+	CustomRules map[string]ruleInfo
+	DataSources []provparse.Resource
+	Resources   []provparse.Resource
+	Results     []issueResult
+}
+
+// ruleDescription returns the short description of a built-in or custom rule.
+func (report *lintReport) ruleDescription(id string) string {
+	if info, ok := report.CustomRules[id]; ok {
+		return info.Description
+	}
+	return ruleInfos[id].Description
+}
+
+// issuePosition returns the position of the issue, provider issues without a
+// position are attributed to the provider.
+func (report *lintReport) issuePosition(res issueResult) token.Position {
+	pos := report.Provider.Fset.Position(res.Issue.Pos)
+	if !pos.IsValid() && res.Resource == nil {
+		pos = report.Provider.Fset.Position(report.Provider.Pos())
+	}
+	return pos
+}
+
+type reportFormatter func(w io.Writer, report *lintReport) error
+
+var reportFormatters = map[string]reportFormatter{
+	"text":       writeTextReport,
+	"json":       writeJSONReport,
+	"sarif":      writeSARIFReport,
+	"checkstyle": writeCheckstyleReport,
+	"junit":      writeJUnitReport,
+	"github":     writeGitHubReport,
+}
+
+func writeTextReport(w io.Writer, report *lintReport) error {
+	counts := map[lint.Severity]int{}
+
+	fmt.Fprintln(w)
+	for _, res := range report.Results {
+		counts[res.Severity]++
+		severityColor := textSeverityColor(res.Severity)
+		line := "[" + color.WhiteString("%s", res.resourceLabel()) + "] " +
+			"[" + severityColor.Sprint(res.RuleID) + "] " +
+			fmt.Sprintf("%s: ", report.Provider.Fset.Position(res.Issue.Pos)) +
+			severityColor.Sprint(res.Severity) + ": " +
+			color.WhiteString("%s", res.Issue.Message)
+
+		fmt.Fprintln(w, line)
+
+		for _, rel := range res.Issue.Related {
+			fmt.Fprintf(w, "    %s: %s\n", report.Provider.Fset.Position(rel.Pos), rel.Message)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d issues found (%d errors, %d warnings, %d info)\n", len(report.Results),
+		counts[lint.SeverityError], counts[lint.SeverityWarning], counts[lint.SeverityInfo])
+
+	return nil
+}
+
+func textSeverityColor(s lint.Severity) *color.Color {
+	switch s {
+	case lint.SeverityInfo:
+		return color.New(color.FgCyan)
+	case lint.SeverityWarning:
+		return color.New(color.FgYellow)
+	}
+	return color.New(color.FgRed)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func writeCheckstyleReport(w io.Writer, report *lintReport) error {
+	files := map[string]*checkstyleFile{}
+	for _, res := range report.Results {
+		pos := report.issuePosition(res)
+		f, ok := files[pos.Filename]
+		if !ok {
+			f = &checkstyleFile{
+				Name: pos.Filename,
+			}
+			files[pos.Filename] = f
+		}
+		f.Errors = append(f.Errors, checkstyleError{
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Severity: res.Severity.String(),
+			Message:  fmt.Sprintf("[%s] %s", res.resourceLabel(), res.Issue.Message),
+			Source:   "tfprovlint." + res.RuleID,
+		})
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cs := &checkstyleReport{
+		Version: "5.0",
+	}
+	for _, name := range names {
+		cs.Files = append(cs.Files, *files[name])
+	}
+
+	return writeXML(w, cs)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(v)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import "testing"
+
+func TestWriteCheckstyleReport(t *testing.T) {
+	assertReport(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="$DIR/data_source_a:b,c.go">
+    <error line="6" column="11" severity="warning" message="[data.test_ds] 100% custom&#xA;message" source="tfprovlint.custom"></error>
+  </file>
+  <file name="$DIR/provider.go">
+    <error line="5" column="6" severity="info" message="[provider] attribute &#34;region&#34; should have a description" source="tfprovlint.tfprovlint030"></error>
+  </file>
+  <file name="$DIR/resource_thing.go">
+    <error line="4" column="2" severity="error" message="[test_thing] attribute &#34;bar&#34; was not found in the schema" source="tfprovlint.tfprovlint002"></error>
+  </file>
+</checkstyle>
+`, writeCheckstyleReport)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+// writeGitHubReport outputs the results as GitHub Actions workflow commands so
+// that they are displayed as annotations on the pull request diff.
+func writeGitHubReport(w io.Writer, report *lintReport) error {
+	paths := moduleRelativePaths{}
+	for _, res := range report.Results {
+		var props []string
+		if pos := report.Provider.Fset.Position(res.Issue.Pos); pos.IsValid() {
+			props = append(props,
+				"file="+escapeGitHubProperty(paths.rel(pos.Filename)),
+				fmt.Sprintf("line=%d", pos.Line),
+				fmt.Sprintf("col=%d", pos.Column),
+			)
+		}
+		props = append(props, "title="+escapeGitHubProperty(res.RuleID))
+
+		msg := fmt.Sprintf("[%s] %s", res.resourceLabel(), res.Issue.Message)
+		_, err := fmt.Fprintf(w, "::%s %s::%s\n", gitHubCommand(res.Severity), strings.Join(props, ","), escapeGitHubData(msg))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func gitHubCommand(s lint.Severity) string {
+	switch s {
+	case lint.SeverityInfo:
+		return "notice"
+	case lint.SeverityWarning:
+		return "warning"
+	}
+	return "error"
+}
+
+var (
+	gitHubDataEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+	)
+	gitHubPropertyEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+		":", "%3A",
+		",", "%2C",
+	)
+)
+
+func escapeGitHubData(s string) string {
+	return gitHubDataEscaper.Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return gitHubPropertyEscaper.Replace(s)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import "testing"
+
+func TestWriteGitHubReport(t *testing.T) {
+	assertReport(t, `::warning file=data_source_a%3Ab%2Cc.go,line=6,col=11,title=custom::[data.test_ds] 100%25 custom%0Amessage
+::error file=resource_thing.go,line=4,col=2,title=tfprovlint002::[test_thing] attribute "bar" was not found in the schema
+::notice title=tfprovlint030::[provider] attribute "region" should have a description
+`, writeGitHubReport)
+}
+
+func TestEscapeGitHubProperty(t *testing.T) {
+	for _, c := range []struct {
+		expected string
+		value    string
+	}{
+		{"resource.go", "resource.go"},
+		{"a%3Ab%2Cc", "a:b,c"},
+		{"100%25", "100%"},
+		{"a%0D%0Ab", "a\r\nb"},
+	} {
+		t.Run(c.value, func(t *testing.T) {
+			actual := escapeGitHubProperty(c.value)
+			if actual != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
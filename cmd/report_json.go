@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonReportVersion is incremented whenever a backwards incompatible change is
+// made to the JSON report format.
+const jsonReportVersion = 1
+
+type jsonReport struct {
+	Version int         `json:"version"`
+	Issues  []jsonIssue `json:"issues"`
+	Summary jsonSummary `json:"summary"`
+}
+
+type jsonIssue struct {
+	RuleID     string `json:"rule_id"`
+	Resource   string `json:"resource"`
+	DataSource bool   `json:"data_source"`
+	Provider   bool   `json:"provider"`
+	Severity   string `json:"severity"`
+	Attribute  string `json:"attribute,omitempty"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Message    string `json:"message"`
+
+	Related []jsonRelatedLocation `json:"related,omitempty"`
+}
+
+type jsonRelatedLocation struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+type jsonSummary struct {
+	Issues      int `json:"issues"`
+	Rules       int `json:"rules"`
+	Resources   int `json:"resources"`
+	DataSources int `json:"data_sources"`
+}
+
+func writeJSONReport(w io.Writer, report *lintReport) error {
+	issues := make([]jsonIssue, 0, len(report.Results))
+	for _, res := range report.Results {
+		pos := report.Provider.Fset.Position(res.Issue.Pos)
+		var related []jsonRelatedLocation
+		for _, rel := range res.Issue.Related {
+			relPos := report.Provider.Fset.Position(rel.Pos)
+			related = append(related, jsonRelatedLocation{
+				File:    relPos.Filename,
+				Line:    relPos.Line,
+				Column:  relPos.Column,
+				Message: rel.Message,
+			})
+		}
+		issues = append(issues, jsonIssue{
+			RuleID:     res.RuleID,
+			Resource:   res.resourceName(),
+			DataSource: res.ReadOnly,
+			Provider:   res.Resource == nil,
+			Severity:   res.Severity.String(),
+			Attribute:  res.Issue.AttributePath,
+			File:       pos.Filename,
+			Line:       pos.Line,
+			Column:     pos.Column,
+			Message:    res.Issue.Message,
+			Related:    related,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&jsonReport{
+		Version: jsonReportVersion,
+		Issues:  issues,
+		Summary: jsonSummary{
+			Issues:      len(issues),
+			Rules:       len(report.Rules),
+			Resources:   len(report.Resources),
+			DataSources: len(report.DataSources),
+		},
+	})
+}
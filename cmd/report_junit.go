@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func writeJUnitReport(w io.Writer, report *lintReport) error {
+	return writeXML(w, &junitTestSuites{
+		Suites: []junitTestSuite{
+			junitProviderTestSuite(report),
+			junitResourceTestSuite(report, true, "data sources", report.DataSources),
+			junitResourceTestSuite(report, false, "resources", report.Resources),
+		},
+	})
+}
+
+// junitProviderTestSuite builds a test suite with a single test case for the
+// issues found by provider rules.
+func junitProviderTestSuite(report *lintReport) junitTestSuite {
+	tc := junitTestCase{
+		Name:      "provider",
+		ClassName: "tfprovlint.provider",
+	}
+	for _, res := range report.Results {
+		if res.Resource == nil {
+			tc.Failures = append(tc.Failures, newJUnitFailure(report, res))
+		}
+	}
+
+	suite := junitTestSuite{
+		Name:      "tfprovlint provider",
+		Tests:     1,
+		TestCases: []junitTestCase{tc},
+	}
+	if len(tc.Failures) > 0 {
+		suite.Failures++
+	}
+	return suite
+}
+
+func newJUnitFailure(report *lintReport, res issueResult) junitFailure {
+	f := junitFailure{
+		Message:  res.Issue.Message,
+		Type:     res.RuleID,
+		Contents: res.Issue.Message,
+	}
+	if pos := report.issuePosition(res); pos.IsValid() {
+		f.Contents = fmt.Sprintf("%s: %s", pos, res.Issue.Message)
+	}
+	return f
+}
+
+// junitResourceTestSuite builds a test suite with a test case per resource, each
+// issue found for the resource is a failure of the test case.
+func junitResourceTestSuite(report *lintReport, readOnly bool, name string, resources []provparse.Resource) junitTestSuite {
+	failures := map[string][]junitFailure{}
+	for _, res := range report.Results {
+		if res.Resource == nil || res.ReadOnly != readOnly {
+			continue
+		}
+		failures[res.Resource.Name] = append(failures[res.Resource.Name], newJUnitFailure(report, res))
+	}
+
+	suite := junitTestSuite{
+		Name:  "tfprovlint " + name,
+		Tests: len(resources),
+	}
+	for _, r := range resources {
+		tc := junitTestCase{
+			Name:      r.Name,
+			ClassName: "tfprovlint." + strings.Replace(name, " ", "_", -1),
+			Failures:  failures[r.Name],
+		}
+		if len(tc.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	return suite
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestWriteJUnitReport(t *testing.T) {
+	assertReport(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="tfprovlint provider" tests="1" failures="1">
+    <testcase name="provider" classname="tfprovlint.provider">
+      <failure message="attribute &#34;region&#34; should have a description" type="tfprovlint030">$DIR/provider.go:5:6: attribute &#34;region&#34; should have a description</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="tfprovlint data sources" tests="1" failures="1">
+    <testcase name="test_ds" classname="tfprovlint.data_sources">
+      <failure message="100% custom&#xA;message" type="custom">$DIR/data_source_a:b,c.go:6:11: 100% custom&#xA;message</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="tfprovlint resources" tests="2" failures="1">
+    <testcase name="test_other" classname="tfprovlint.resources"></testcase>
+    <testcase name="test_thing" classname="tfprovlint.resources">
+      <failure message="attribute &#34;bar&#34; was not found in the schema" type="tfprovlint002">$DIR/resource_thing.go:4:2: attribute &#34;bar&#34; was not found in the schema</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, writeJUnitReport)
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"net/url"
+	"path/filepath"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	// sarifSrcRoot is the base ID of module relative artifact locations.
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+
+	RelatedLocations []sarifLocation  `json:"relatedLocations,omitempty"`
+	Properties       *sarifProperties `json:"properties,omitempty"`
+}
+
+type sarifProperties struct {
+	AttributePath string `json:"attributePath,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeSARIFReport(w io.Writer, report *lintReport) error {
+	ids := sortedRuleIDs(report.Rules)
+
+	descriptors := make([]sarifReportingDescriptor, 0, len(ids))
+	ruleIndexes := make(map[string]int, len(ids))
+	for i, id := range ids {
+		descriptors = append(descriptors, sarifReportingDescriptor{
+			ID: id,
+			ShortDescription: sarifMessage{
+				Text: report.ruleDescription(id),
+			},
+		})
+		ruleIndexes[id] = i
+	}
+
+	artifacts := &sarifArtifacts{
+		paths: moduleRelativePaths{},
+	}
+
+	results := make([]sarifResult, 0, len(report.Results))
+	for _, res := range report.Results {
+		result := sarifResult{
+			RuleID: res.RuleID,
+			Level:  sarifLevel(res.Severity),
+			Message: sarifMessage{
+				Text: res.Issue.Message,
+			},
+		}
+		if i, ok := ruleIndexes[res.RuleID]; ok {
+			result.RuleIndex = &i
+		}
+
+		if pos := report.Provider.Fset.Position(res.Issue.Pos); pos.IsValid() {
+			result.Locations = []sarifLocation{
+				{
+					PhysicalLocation: artifacts.physicalLocation(pos),
+				},
+			}
+		}
+
+		for _, rel := range res.Issue.Related {
+			pos := report.Provider.Fset.Position(rel.Pos)
+			if !pos.IsValid() {
+				continue
+			}
+			result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+				ID:               len(result.RelatedLocations) + 1,
+				PhysicalLocation: artifacts.physicalLocation(pos),
+				Message: &sarifMessage{
+					Text: rel.Message,
+				},
+			})
+		}
+
+		if res.Issue.AttributePath != "" {
+			result.Properties = &sarifProperties{
+				AttributePath: res.Issue.AttributePath,
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	var baseIDs map[string]sarifArtifactLocation
+	if artifacts.root != "" {
+		baseIDs = map[string]sarifArtifactLocation{
+			sarifSrcRoot: {
+				URI: sarifFileURI(artifacts.root) + "/",
+			},
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "tfprovlint",
+						InformationURI: "https://github.com/paultyng/tfprovlint",
+						Rules:          descriptors,
+					},
+				},
+				Results:            results,
+				OriginalURIBaseIDs: baseIDs,
+			},
+		},
+	})
+}
+
+// sarifArtifacts builds the locations of files, relative to the root of the
+// first module found. Files outside of that module use absolute URIs.
+type sarifArtifacts struct {
+	paths moduleRelativePaths
+	root  string
+}
+
+func (a *sarifArtifacts) artifactLocation(filename string) sarifArtifactLocation {
+	rel, root, ok := a.paths.relRoot(filename)
+	if !ok || (a.root != "" && a.root != root) {
+		return sarifArtifactLocation{
+			URI: sarifFileURI(filename),
+		}
+	}
+	a.root = root
+	u := &url.URL{
+		Path: rel,
+	}
+	return sarifArtifactLocation{
+		URI:       u.String(),
+		URIBaseID: sarifSrcRoot,
+	}
+}
+
+func (a *sarifArtifacts) physicalLocation(pos token.Position) sarifPhysicalLocation {
+	return sarifPhysicalLocation{
+		ArtifactLocation: a.artifactLocation(pos.Filename),
+		Region: sarifRegion{
+			StartLine:   pos.Line,
+			StartColumn: pos.Column,
+		},
+	}
+}
+
+func sarifLevel(s lint.Severity) string {
+	switch s {
+	case lint.SeverityInfo:
+		return "note"
+	case lint.SeverityWarning:
+		return "warning"
+	}
+	return "error"
+}
+
+func sarifFileURI(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	u := &url.URL{
+		Scheme: "file",
+		Path:   filepath.ToSlash(filename),
+	}
+	return u.String()
+}
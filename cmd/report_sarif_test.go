@@ -0,0 +1,114 @@
+package cmd
+
+import "testing"
+
+func TestWriteSARIFReport(t *testing.T) {
+	assertReport(t, `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "tfprovlint",
+          "informationUri": "https://github.com/paultyng/tfprovlint",
+          "rules": [
+            {
+              "id": "tfprovlint002",
+              "shortDescription": {
+                "text": "Only set attributes described in Schema"
+              }
+            },
+            {
+              "id": "tfprovlint030",
+              "shortDescription": {
+                "text": "Resource and data source names should start with the provider name"
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "custom",
+          "level": "warning",
+          "message": {
+            "text": "100% custom\nmessage"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "./data_source_a:b,c.go",
+                  "uriBaseId": "%SRCROOT%"
+                },
+                "region": {
+                  "startLine": 6,
+                  "startColumn": 11
+                }
+              }
+            }
+          ],
+          "relatedLocations": [
+            {
+              "id": 1,
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "./data_source_a:b,c.go",
+                  "uriBaseId": "%SRCROOT%"
+                },
+                "region": {
+                  "startLine": 4,
+                  "startColumn": 9
+                }
+              },
+              "message": {
+                "text": "resource defined here"
+              }
+            }
+          ],
+          "properties": {
+            "attributePath": "foo"
+          }
+        },
+        {
+          "ruleId": "tfprovlint002",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {
+            "text": "attribute \"bar\" was not found in the schema"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "resource_thing.go",
+                  "uriBaseId": "%SRCROOT%"
+                },
+                "region": {
+                  "startLine": 4,
+                  "startColumn": 2
+                }
+              }
+            }
+          ]
+        },
+        {
+          "ruleId": "tfprovlint030",
+          "ruleIndex": 1,
+          "level": "note",
+          "message": {
+            "text": "attribute \"region\" should have a description"
+          }
+        }
+      ],
+      "originalUriBaseIds": {
+        "%SRCROOT%": {
+          "uri": "file://$DIR/"
+        }
+      }
+    }
+  ]
+}
+`, writeSARIFReport)
+}
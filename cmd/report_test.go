@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa/ssautil"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+const (
+	testReportSchemaSrc = `package schema
+
+type Provider struct {
+	ResourcesMap map[string]*Resource
+}
+
+type Resource struct{}
+`
+	testReportProviderSrc = `package test
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{}
+}
+`
+	testReportResourceSrc = `package test
+
+func resourceThingDelete(d *schema.ResourceData, meta interface{}) error {
+	d.Set("bar", "")
+	return nil
+}
+`
+	testReportDataSourceSrc = `package test
+
+func dataSourceThing() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"foo": {Type: schema.TypeString},
+		},
+	}
+}
+`
+)
+
+// newTestReport builds a report for files in a temporary module, the returned
+// directory is the root of the module and should be removed by the caller.
+func newTestReport(t *testing.T) (*lintReport, string) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "tfprovlint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/test\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prov := parseTestReportProvider(t, filepath.Join(dir, "provider.go"))
+	prov.Name = "test"
+
+	fset := prov.Fset
+	addFile := func(name, src string) *token.File {
+		f := fset.AddFile(filepath.Join(dir, name), -1, len(src))
+		f.SetLinesForContent([]byte(src))
+		return f
+	}
+	pos := func(f *token.File, line, column int) token.Pos {
+		return f.LineStart(line) + token.Pos(column-1)
+	}
+	resourceFile := addFile("resource_thing.go", testReportResourceSrc)
+	dataSourceFile := addFile("data_source_a:b,c.go", testReportDataSourceSrc)
+
+	report := &lintReport{
+		Provider: prov,
+		Rules: map[string]bool{
+			"tfprovlint002": true,
+			"tfprovlint030": true,
+		},
+		CustomRules: map[string]ruleInfo{
+			"custom": {Description: "A custom rule"},
+		},
+		DataSources: []provparse.Resource{{Name: "test_ds"}},
+		Resources:   []provparse.Resource{{Name: "test_other"}, {Name: "test_thing"}},
+	}
+	report.Results = []issueResult{
+		{
+			ReadOnly: true,
+			Resource: &report.DataSources[0],
+			RuleID:   "custom",
+			Severity: lint.SeverityWarning,
+			Issue: lint.Issue{
+				Pos:           pos(dataSourceFile, 6, 11),
+				Message:       "100% custom\nmessage",
+				AttributePath: "foo",
+				Related: []lint.RelatedLocation{
+					{Pos: pos(dataSourceFile, 4, 9), Message: "resource defined here"},
+				},
+			},
+		},
+		{
+			Resource: &report.Resources[1],
+			RuleID:   "tfprovlint002",
+			Severity: lint.SeverityError,
+			Issue: lint.Issue{
+				Pos:     pos(resourceFile, 4, 2),
+				Message: `attribute "bar" was not found in the schema`,
+			},
+		},
+		{
+			RuleID:   "tfprovlint030",
+			Severity: lint.SeverityInfo,
+			Issue: lint.Issue{
+				Pos:     token.NoPos,
+				Message: `attribute "region" should have a description`,
+			},
+		},
+	}
+
+	return report, dir
+}
+
+// parseTestReportProvider parses the provider source against a stub of the
+// schema package so that the provider has a position.
+func parseTestReportProvider(t *testing.T, filename string) *provparse.Provider {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	schemaFile, err := parser.ParseFile(fset, "schema.go", testReportSchemaSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaPkg, err := (&types.Config{}).Check("github.com/hashicorp/terraform/helper/schema", fset, []*ast.File{schemaFile}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parser.ParseFile(fset, filename, testReportProviderSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []*ast.File{f}
+	conf := &types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			return schemaPkg, nil
+		}),
+	}
+	pkg, _, err := ssautil.BuildPackage(conf, fset, types.NewPackage("example.com/test", "test"), files, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prov, err := provparse.SSAPackage(pkg, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prov
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// assertReport compares the output of a formatter for the test report, the
+// module directory is replaced with $DIR in the output.
+func assertReport(t *testing.T, expected string, formatter reportFormatter) {
+	t.Helper()
+
+	report, dir := newTestReport(t)
+	defer os.RemoveAll(dir)
+
+	buf := &bytes.Buffer{}
+	err := formatter(buf, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := strings.Replace(buf.String(), filepath.ToSlash(dir), "$DIR", -1)
+	if actual != expected {
+		t.Fatalf("unexpected report:\n%s", actual)
+	}
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	assertReport(t, `{
+  "version": 1,
+  "issues": [
+    {
+      "rule_id": "custom",
+      "resource": "test_ds",
+      "data_source": true,
+      "provider": false,
+      "severity": "warning",
+      "attribute": "foo",
+      "file": "$DIR/data_source_a:b,c.go",
+      "line": 6,
+      "column": 11,
+      "message": "100% custom\nmessage",
+      "related": [
+        {
+          "file": "$DIR/data_source_a:b,c.go",
+          "line": 4,
+          "column": 9,
+          "message": "resource defined here"
+        }
+      ]
+    },
+    {
+      "rule_id": "tfprovlint002",
+      "resource": "test_thing",
+      "data_source": false,
+      "provider": false,
+      "severity": "error",
+      "file": "$DIR/resource_thing.go",
+      "line": 4,
+      "column": 2,
+      "message": "attribute \"bar\" was not found in the schema"
+    },
+    {
+      "rule_id": "tfprovlint030",
+      "resource": "",
+      "data_source": false,
+      "provider": true,
+      "severity": "info",
+      "file": "",
+      "line": 0,
+      "column": 0,
+      "message": "attribute \"region\" should have a description"
+    }
+  ],
+  "summary": {
+    "issues": 3,
+    "rules": 2,
+    "resources": 2,
+    "data_sources": 1
+  }
+}
+`, writeJSONReport)
+}
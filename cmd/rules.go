@@ -1,12 +1,117 @@
 package cmd
 
 import (
+	"bytes"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mitchellh/cli"
+
 	"github.com/paultyng/tfprovlint/lint"
 	"github.com/paultyng/tfprovlint/rules"
 )
 
 type ruleFactoryFunc func() lint.ResourceRule
 
+type providerRuleFactoryFunc func() lint.ProviderRule
+
+// ruleCategory indicates what part of the provider a rule inspects.
+type ruleCategory string
+
+const (
+	// categorySchema rules inspect the attribute schema.
+	categorySchema ruleCategory = "schema"
+	// categoryResource rules inspect the resource definition.
+	categoryResource ruleCategory = "resource"
+	// categoryRuntime rules inspect the code of the resource functions.
+	categoryRuntime ruleCategory = "runtime"
+	// categoryProvider rules inspect the provider as a whole.
+	categoryProvider ruleCategory = "provider"
+	// categoryPlugin rules are implemented by plugins.
+	categoryPlugin ruleCategory = "plugin"
+)
+
+// ruleInfo is the metadata describing a rule.
+type ruleInfo struct {
+	Description string
+	Category    ruleCategory
+	Severity    lint.Severity
+
+	// FalsePositives indicates the rule is prone to reporting issues for valid code.
+	FalsePositives bool
+
+	Doc rules.Documentation
+}
+
+var ruleInfos = map[string]ruleInfo{
+	"tfprovlint001": {
+		Description: "Do not call `d.SetId(\"\")` in a `DeleteFunc`",
+		Category:    categoryRuntime,
+		Severity:    lint.SeverityError,
+		Doc:         rules.NoSetIdInDeleteFuncRuleDoc,
+	},
+	"tfprovlint002": {
+		Description:    "Only set attributes described in Schema",
+		Category:       categoryRuntime,
+		Severity:       lint.SeverityWarning,
+		FalsePositives: true,
+		Doc:            rules.SetAttributeNameExistsRuleDoc,
+	},
+	"tfprovlint003": {
+		Description: "Use the proper type when setting an attribute",
+		Category:    categoryRuntime,
+		Severity:    lint.SeverityError,
+		Doc:         rules.UseProperAttributeTypesInSetRuleDoc,
+	},
+	"tfprovlint005": {
+		Description: "Do not dereference a pointer value before calling `d.Set`",
+		Category:    categoryRuntime,
+		Severity:    lint.SeverityError,
+		Doc:         rules.DoNotDereferencePointersInSetRuleDoc,
+	},
+	"tfprovlint026": {
+		Description: "Do not use reserved field names",
+		Category:    categoryResource,
+		Severity:    lint.SeverityError,
+		Doc:         rules.NoReservedNamesRuleDoc,
+	},
+	"tfprovlint029": {
+		Description: "Resource functions should call `fmt.Errorf` instead of `errwrap.Wrapf`",
+		Category:    categoryRuntime,
+		Severity:    lint.SeverityWarning,
+		Doc:         rules.NoErrwrapWrapfInResourceFuncRuleDoc,
+	},
+	"tfprovlint030": {
+		Description: "Resource and data source names should start with the provider name",
+		Category:    categoryProvider,
+		Severity:    lint.SeverityError,
+		Doc:         rules.ResourceNamePrefixRuleDoc,
+	},
+	"tfprovlint031": {
+		Description:    "Do not register the same resource function under multiple names",
+		Category:       categoryProvider,
+		Severity:       lint.SeverityWarning,
+		FalsePositives: true,
+		Doc:            rules.NoDuplicateRegistrationRuleDoc,
+	},
+	"tfprovlint032": {
+		Description: "CreateFunc must call `d.SetId`",
+		Category:    categoryRuntime,
+		Severity:    lint.SeverityError,
+		Doc:         rules.SetIdInCreateFuncRuleDoc,
+	},
+	"tfprovlint033": {
+		Description:    "ReadFunc must call `d.Set`",
+		Category:       categoryRuntime,
+		Severity:       lint.SeverityWarning,
+		FalsePositives: true,
+		Doc:            rules.SetInReadFuncRuleDoc,
+	},
+}
+
 var resourceRules = map[string]ruleFactoryFunc{
 	"tfprovlint001": rules.NewNoSetIdInDeleteFuncRule,
 	"tfprovlint002": rules.NewSetAttributeNameExistsRule,
@@ -15,27 +120,122 @@ var resourceRules = map[string]ruleFactoryFunc{
 	"tfprovlint005": rules.NewDoNotDereferencePointersInSetRule,
 	"tfprovlint026": rules.NewNoReservedNamesRule,
 	"tfprovlint029": rules.NewNoErrwrapWrapfInResourceFuncRule,
+	"tfprovlint032": rules.NewSetIdInCreateFuncRule,
+	"tfprovlint033": rules.NewSetInReadFuncRule,
 }
 
-func loadRules(includes, excludes []string) map[string]ruleFactoryFunc {
-	var filtered map[string]ruleFactoryFunc
+// providerRules are evaluated once against the whole provider. A rule ID may be
+// registered as both a resource and a provider rule.
+var providerRules = map[string]providerRuleFactoryFunc{
+	"tfprovlint026": rules.NewNoReservedProviderNamesRule,
+	"tfprovlint030": rules.NewResourceNamePrefixRule,
+	"tfprovlint031": rules.NewNoDuplicateRegistrationRule,
+}
+
+// knownRuleIDs returns the IDs of all registered rules.
+func knownRuleIDs() map[string]bool {
+	ids := make(map[string]bool, len(resourceRules)+len(providerRules))
+	for id := range resourceRules {
+		ids[id] = true
+	}
+	for id := range providerRules {
+		ids[id] = true
+	}
+	return ids
+}
+
+func copyRuleIDs(ids map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(ids))
+	for id := range ids {
+		copied[id] = true
+	}
+	return copied
+}
+
+// loadRules returns the IDs of the known rules to evaluate. Rules are enabled or
+// disabled by the configuration, but an include list replaces the configured
+// set and excludes are always removed.
+func loadRules(known map[string]bool, cfg *config, includes, excludes []string) map[string]bool {
+	filtered := map[string]bool{}
 	if len(includes) == 0 {
-		filtered = resourceRules
+		for id := range known {
+			if cfg.ruleEnabled(id) {
+				filtered[id] = true
+			}
+		}
 	} else {
-		filtered = make(map[string]ruleFactoryFunc, len(includes))
 		for _, id := range includes {
-			if rule, ok := resourceRules[id]; ok {
-				filtered[id] = rule
+			if known[id] {
+				filtered[id] = true
 			}
 		}
 	}
-	if len(excludes) > 0 {
-		for _, id := range excludes {
-			if _, ok := filtered[id]; ok {
-				delete(filtered, id)
-			}
-		}
+	for _, id := range excludes {
+		delete(filtered, id)
 	}
 
 	return filtered
 }
+
+func sortedRuleIDs(rules map[string]bool) []string {
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type rulesCommand struct {
+	UI cli.Ui
+}
+
+func (c *rulesCommand) Help() string {
+	return strings.TrimSpace(`
+Usage: tfprovlint rules
+
+  Lists all of the rules with their metadata.
+`)
+}
+
+func (c *rulesCommand) Synopsis() string {
+	return "Lists the available rules"
+}
+
+func (c *rulesCommand) Run(args []string) int {
+	flags := flag.NewFlagSet("rules", flag.ContinueOnError)
+	err := flags.Parse(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return exitCodeError
+	}
+
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCATEGORY\tSEVERITY\tFALSE POSITIVES\tDESCRIPTION")
+	for _, id := range sortedRuleIDs(knownRuleIDs()) {
+		info := ruleInfos[id]
+		falsePositives := "no"
+		if info.FalsePositives {
+			falsePositives = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, info.Category, info.Severity, falsePositives, info.Description)
+	}
+	err = w.Flush()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return exitCodeError
+	}
+
+	c.UI.Output(strings.TrimSuffix(buf.String(), "\n"))
+
+	return exitCodeOK
+}
+
+func RulesCommandFactory(ui cli.Ui) cli.CommandFactory {
+	return func() (cli.Command, error) {
+		return &rulesCommand{
+			UI: ui,
+		}, nil
+	}
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestRuleInfos(t *testing.T) {
+	for id := range knownRuleIDs() {
+		info, ok := ruleInfos[id]
+		if !ok {
+			t.Errorf("no metadata for rule %s", id)
+			continue
+		}
+		if info.Description == "" || info.Category == "" || info.Doc.Rationale == "" {
+			t.Errorf("incomplete metadata for rule %s", id)
+		}
+	}
+	known := knownRuleIDs()
+	for id := range ruleInfos {
+		if !known[id] {
+			t.Errorf("metadata for unregistered rule %s", id)
+		}
+	}
+}
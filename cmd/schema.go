@@ -25,18 +25,18 @@ func (c *schemaCommand) Run(args []string) int {
 	err := flags.Parse(args)
 	if err != nil {
 		c.UI.Error(err.Error())
-		return -1
+		return exitCodeError
 	}
 
 	prov, err := parseProvider(flags.Args())
 	if err != nil {
 		c.UI.Error(err.Error())
-		return -1
+		return exitCodeError
 	}
 
 	if len(prov.DataSources) > 0 {
 		c.UI.Output("Data Sources:")
-		c.outputResources(prov.Resources)
+		c.outputResources(prov.DataSources)
 	}
 
 	if len(prov.Resources) > 0 {
@@ -44,7 +44,7 @@ func (c *schemaCommand) Run(args []string) int {
 		c.outputResources(prov.Resources)
 	}
 
-	return 0
+	return exitCodeOK
 }
 
 func (c *schemaCommand) outputResources(resources []provparse.Resource) {
@@ -56,7 +56,7 @@ func (c *schemaCommand) outputResources(resources []provparse.Resource) {
 	}
 }
 func (c *schemaCommand) outputAttribute(att provparse.Attribute, prefix string) {
-	c.UI.Output(prefix + color.WhiteString(att.Name))
+	c.UI.Output(prefix + color.WhiteString(att.Name) + " " + att.TypeName())
 	if len(att.Attributes) > 0 {
 		prefix += "\t"
 		for _, child := range att.Attributes {
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// suppressionDirective is the comment prefix used to ignore issues, for example:
+//
+//	//tfprovlint:ignore tfprovlint002,tfprovlint003 reason for ignoring
+//
+// A directive on a line of its own applies to the line following it, a directive
+// trailing code applies only to its own line. When used in a function's doc
+// comment the directive applies to the entire function.
+const suppressionDirective = "//tfprovlint:ignore"
+
+type suppression struct {
+	RuleIDs []string
+	Reason  string
+	Pos     token.Pos
+
+	filename  string
+	startLine int
+	endLine   int
+	used      map[string]bool
+}
+
+func (s *suppression) matches(pos token.Position, ruleID string) bool {
+	if pos.Filename != s.filename || pos.Line < s.startLine || pos.Line > s.endLine {
+		return false
+	}
+	for _, id := range s.RuleIDs {
+		if id == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+type suppressions []*suppression
+
+func parseSuppressions(fset *token.FileSet, files []*ast.File) suppressions {
+	var all suppressions
+
+	for _, f := range files {
+		funcDocs := map[*ast.CommentGroup]*ast.FuncDecl{}
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Doc != nil {
+				funcDocs[fd.Doc] = fd
+			}
+		}
+
+		codeEnds := codeLineEnds(fset, f)
+
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				s := parseSuppressionComment(c)
+				if s == nil {
+					continue
+				}
+
+				pos := fset.Position(c.Pos())
+				s.filename = pos.Filename
+				if fd, ok := funcDocs[cg]; ok {
+					s.startLine = pos.Line
+					s.endLine = fset.Position(fd.End()).Line
+				} else if end, ok := codeEnds[pos.Line]; ok && end <= c.Pos() {
+					// trailing comment
+					s.startLine = pos.Line
+					s.endLine = pos.Line
+				} else {
+					s.startLine = pos.Line
+					s.endLine = pos.Line + 1
+				}
+
+				all = append(all, s)
+			}
+		}
+	}
+
+	return all
+}
+
+// codeLineEnds returns the position of the first end of code on each line of the
+// file, it is used to tell trailing comments from comments on a line of their own.
+func codeLineEnds(fset *token.FileSet, f *ast.File) map[int]token.Pos {
+	ends := map[int]token.Pos{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.File:
+			return true
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		line := fset.Position(n.End()).Line
+		if end, ok := ends[line]; !ok || n.End() < end {
+			ends[line] = n.End()
+		}
+		return true
+	})
+	return ends
+}
+
+func parseSuppressionComment(c *ast.Comment) *suppression {
+	if !strings.HasPrefix(c.Text, suppressionDirective) {
+		return nil
+	}
+	rest := strings.TrimPrefix(c.Text, suppressionDirective)
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		// some other directive with the same prefix
+		return nil
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &suppression{
+		RuleIDs: strings.Split(fields[0], ","),
+		Reason:  strings.Join(fields[1:], " "),
+		Pos:     c.Pos(),
+		used:    map[string]bool{},
+	}
+}
+
+// apply removes any suppressed results and marks the matching suppressions as used.
+func (all suppressions) apply(fset *token.FileSet, results []issueResult) []issueResult {
+	if len(all) == 0 {
+		return results
+	}
+
+	filtered := make([]issueResult, 0, len(results))
+	for _, res := range results {
+		pos := fset.Position(res.Issue.Pos)
+		suppressed := false
+		for _, s := range all {
+			if s.matches(pos, res.RuleID) {
+				s.used[res.RuleID] = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			filtered = append(filtered, res)
+		}
+	}
+
+	return filtered
+}
+
+// excludeFiles returns the suppressions that are not in excluded files.
+func (all suppressions) excludeFiles(excluded func(filename string) bool) suppressions {
+	var kept suppressions
+	for _, s := range all {
+		if !excluded(s.filename) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// unused returns messages describing suppressions of evaluated rules that did
+// not match any issue.
+func (all suppressions) unused(fset *token.FileSet, rules map[string]bool) []string {
+	var msgs []string
+	for _, s := range all {
+		for _, id := range s.RuleIDs {
+			if !rules[id] || s.used[id] {
+				continue
+			}
+			msgs = append(msgs, fmt.Sprintf("%s: unused %s directive for %s", fset.Position(s.Pos), strings.TrimPrefix(suppressionDirective, "//"), id))
+		}
+	}
+	return msgs
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+const suppressionsSrc = `package test
+
+func read(d *ResourceData) {
+	//tfprovlint:ignore tfprovlint002 not in schema on purpose
+	d.Set("a", 1)
+	d.Set("b", 1) //tfprovlint:ignore tfprovlint002,tfprovlint003
+
+	d.Set("c", 1)
+
+	d.Set("g", 1) //tfprovlint:ignore tfprovlint002 trailing
+	d.Set("h", 1)
+}
+
+//tfprovlint:ignore tfprovlint005 legacy code
+func update(d *ResourceData) {
+	d.Set("d", 1)
+
+	d.Set("e", 1)
+}
+
+//tfprovlint:ignore tfprovlint001
+func unused() {}
+
+//tfprovlint:ignored tfprovlint002
+func otherDirective(d *ResourceData) {
+	d.Set("f", 1)
+}
+`
+
+func TestSuppressions(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "test.go", suppressionsSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// map the attribute name set to the position of the call
+	setPos := map[string]token.Pos{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			setPos[call.Args[0].(*ast.BasicLit).Value] = call.Lparen
+		}
+		return true
+	})
+
+	result := func(ruleID, att string) issueResult {
+		return issueResult{
+			Resource: &provparse.Resource{Name: "test"},
+			RuleID:   ruleID,
+			Issue:    lint.NewIssuef(setPos[`"`+att+`"`], "issue for %s", att),
+		}
+	}
+
+	all := parseSuppressions(fset, []*ast.File{f})
+	if len(all) != 5 {
+		t.Fatalf("expected 5 suppressions, found %d", len(all))
+	}
+
+	actual := all.apply(fset, []issueResult{
+		result("tfprovlint002", "a"),
+		result("tfprovlint003", "a"),
+		result("tfprovlint003", "b"),
+		result("tfprovlint002", "c"),
+		result("tfprovlint005", "d"),
+		result("tfprovlint005", "e"),
+		result("tfprovlint002", "e"),
+		result("tfprovlint002", "f"),
+		result("tfprovlint002", "g"),
+		result("tfprovlint002", "h"),
+	})
+
+	expected := []string{
+		"tfprovlint003 issue for a",
+		"tfprovlint002 issue for c",
+		"tfprovlint002 issue for e",
+		"tfprovlint002 issue for f",
+		"tfprovlint002 issue for h",
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d results, found %d", len(expected), len(actual))
+	}
+	for i, res := range actual {
+		if msg := res.RuleID + " " + res.Issue.Message; msg != expected[i] {
+			t.Fatalf("expected %q, found %q", expected[i], msg)
+		}
+	}
+
+	unused := all.unused(fset, map[string]bool{
+		"tfprovlint001": true,
+		"tfprovlint002": true,
+	})
+	expectedUnused := []string{
+		"test.go:6:16: unused tfprovlint:ignore directive for tfprovlint002",
+		"test.go:21:1: unused tfprovlint:ignore directive for tfprovlint001",
+	}
+	if len(unused) != len(expectedUnused) {
+		t.Fatalf("unexpected unused suppressions %q", unused)
+	}
+	for i, msg := range unused {
+		if msg != expectedUnused[i] {
+			t.Fatalf("expected %q, found %q", expectedUnused[i], msg)
+		}
+	}
+}
+
+func TestSuppressions_excludeFiles(t *testing.T) {
+	all := suppressions{
+		{RuleIDs: []string{"tfprovlint002"}, filename: "resource_a.go", used: map[string]bool{}},
+		{RuleIDs: []string{"tfprovlint002"}, filename: "vendor/resource_b.go", used: map[string]bool{}},
+	}
+
+	kept := all.excludeFiles(func(filename string) bool {
+		return strings.HasPrefix(filename, "vendor/")
+	})
+	if len(kept) != 1 || kept[0].filename != "resource_a.go" {
+		t.Fatalf("unexpected suppressions %#v", kept)
+	}
+}
@@ -11,6 +11,43 @@ import (
 type Issue struct {
 	Message string
 	Pos     token.Pos
+
+	// Severity overrides the default severity of the rule for this issue, it
+	// can still be overridden by configuration.
+	Severity Severity
+
+	// Fixes are optional changes to the source code that resolve the issue.
+	Fixes []SuggestedFix
+
+	// AttributePath is the path of the attribute the issue relates to, if any,
+	// in the flatmap style used by Terraform, for example
+	// "ebs_block_device.0.volume_size".
+	AttributePath string
+
+	// Related are other locations relevant to the issue, for example the
+	// schema definition of the attribute.
+	Related []RelatedLocation
+}
+
+// RelatedLocation is a secondary location of an issue.
+type RelatedLocation struct {
+	Pos     token.Pos
+	Message string
+}
+
+// SuggestedFix is a change to the source code that resolves an issue.
+type SuggestedFix struct {
+	// Message describes the change, for example "Remove the dereference".
+	Message string
+	Edits   []TextEdit
+}
+
+// TextEdit replaces the source between Pos and End with NewText. Pos and End
+// must be in the same file, an empty range inserts NewText.
+type TextEdit struct {
+	Pos     token.Pos
+	End     token.Pos
+	NewText []byte
 }
 
 // NewIssuef is a helper to create an issue from a string format.
@@ -0,0 +1,40 @@
+package lint
+
+import "fmt"
+
+// Severity indicates how serious an issue is.
+type Severity int
+
+// Severities are ordered from least to most serious.
+const (
+	// SeverityDefault indicates that no severity was specified and the default
+	// should be used.
+	SeverityDefault Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityDefault: "default",
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// ParseSeverity parses the name of a severity (error, warning, or info).
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if s != SeverityDefault && n == name {
+			return s, nil
+		}
+	}
+	return SeverityDefault, fmt.Errorf("unknown severity %q, expected error, warning, or info", name)
+}
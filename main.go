@@ -25,9 +25,11 @@ func main() {
 	lintFact := cmd.LintCommandFactory(ui)
 
 	c.Commands = map[string]cli.CommandFactory{
-		"":       lintFact, // this no longer crashes but also not matched
-		"lint":   lintFact,
-		"schema": cmd.SchemaCommandFactory(ui),
+		"":        lintFact, // this no longer crashes but also not matched
+		"lint":    lintFact,
+		"explain": cmd.ExplainCommandFactory(ui),
+		"rules":   cmd.RulesCommandFactory(ui),
+		"schema":  cmd.SchemaCommandFactory(ui),
 	}
 
 	exitStatus, err := c.Run()
@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Client runs a plugin executable.
+type Client struct {
+	Command string
+	Args    []string
+}
+
+// Rules returns the rules implemented by the plugin.
+func (c *Client) Rules() ([]Rule, error) {
+	resp, err := c.call(&Request{
+		Version: ProtocolVersion,
+		Method:  MethodRules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Rules, nil
+}
+
+// Check returns the issues found in the provider by the plugin's enabled rules.
+func (c *Client) Check(prov *Provider, rules []string) ([]Issue, error) {
+	resp, err := c.call(&Request{
+		Version:  ProtocolVersion,
+		Method:   MethodCheck,
+		Provider: prov,
+		Rules:    rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Issues, nil
+}
+
+func (c *Client) call(req *Request) (*Response, error) {
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	cmd := exec.Command(c.Command, c.Args...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %s", c.Command, err)
+	}
+
+	var resp Response
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: unable to decode response: %s", c.Command, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", c.Command, resp.Error)
+	}
+	return &resp, nil
+}
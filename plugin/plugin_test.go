@@ -0,0 +1,122 @@
+package plugin
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// testPlugin reports an issue for every resource without a "tags" attribute.
+type testPlugin struct{}
+
+func (testPlugin) Rules() []Rule {
+	return []Rule{
+		{ID: "example001", Description: "Resources must have tags", Severity: "warning"},
+	}
+}
+
+func (testPlugin) Check(prov *Provider, rules map[string]bool) ([]Issue, error) {
+	if !rules["example001"] {
+		return nil, nil
+	}
+	var issues []Issue
+	for _, r := range prov.Resources {
+		found := false
+		for _, att := range r.Attributes {
+			found = found || att.Name == "tags"
+		}
+		if !found {
+			issues = append(issues, Issue{
+				RuleID:   "example001",
+				Resource: r.Name,
+				Position: r.Position,
+				Message:  fmt.Sprintf("resource %q has no tags attribute", r.Name),
+			})
+		}
+	}
+	return issues, nil
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv("TFPROVLINT_TEST_PLUGIN") == "1" {
+		Serve(testPlugin{})
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func testClient(t *testing.T) *Client {
+	t.Helper()
+
+	os.Setenv("TFPROVLINT_TEST_PLUGIN", "1")
+	return &Client{
+		Command: os.Args[0],
+	}
+}
+
+func TestClient_Rules(t *testing.T) {
+	c := testClient(t)
+	rules, err := c.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rules, testPlugin{}.Rules()) {
+		t.Fatalf("unexpected rules %#v", rules)
+	}
+}
+
+func TestClient_Check(t *testing.T) {
+	c := testClient(t)
+	prov := &Provider{
+		Resources: []Resource{
+			{Name: "example_tagged", Attributes: []Attribute{{Name: "tags", Type: "TypeMap"}}},
+			{Name: "example_untagged", Position: Position{Filename: "untagged.go", Line: 3, Column: 6}},
+		},
+	}
+
+	issues, err := c.Check(prov, []string{"example001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Issue{
+		{
+			RuleID:   "example001",
+			Resource: "example_untagged",
+			Position: Position{Filename: "untagged.go", Line: 3, Column: 6},
+			Message:  `resource "example_untagged" has no tags attribute`,
+		},
+	}
+	if !reflect.DeepEqual(issues, expected) {
+		t.Fatalf("unexpected issues %#v", issues)
+	}
+
+	issues, err = c.Check(prov, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("expected no issues for disabled rules, found %d", len(issues))
+	}
+}
+
+func TestPosition_TokenPos(t *testing.T) {
+	fset := token.NewFileSet()
+	f := fset.AddFile("a.go", -1, 20)
+	f.SetLinesForContent([]byte("package a\n\nvar b\n"))
+
+	for _, c := range []struct {
+		expected token.Position
+		pos      Position
+	}{
+		{token.Position{Filename: "a.go", Offset: 15, Line: 3, Column: 5}, Position{"a.go", 3, 5}},
+		{token.Position{Filename: "a.go", Offset: 11, Line: 3, Column: 1}, Position{"a.go", 3, 0}},
+		{token.Position{}, Position{"b.go", 3, 5}},
+	} {
+		actual := fset.Position(c.pos.TokenPos(fset))
+		if actual != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Package plugin implements a JSON protocol over stdin and stdout for running
+// tfprovlint rules in external processes.
+//
+// A plugin is an executable that reads a single Request from stdin, writes a
+// single Response to stdout, and exits. Plugins written in Go can use Serve to
+// implement the protocol.
+package plugin
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// ProtocolVersion is incremented whenever a backwards incompatible change is
+// made to the protocol.
+const ProtocolVersion = 1
+
+// Methods that can be requested of a plugin.
+const (
+	// MethodRules requests the rules implemented by the plugin.
+	MethodRules = "rules"
+	// MethodCheck requests the issues found by the plugin's rules.
+	MethodCheck = "check"
+)
+
+// Request is sent to the plugin on stdin.
+type Request struct {
+	Version int    `json:"version"`
+	Method  string `json:"method"`
+
+	// Provider is the provider to check, only set for MethodCheck.
+	Provider *Provider `json:"provider,omitempty"`
+	// Rules are the IDs of the enabled rules, only set for MethodCheck.
+	Rules []string `json:"rules,omitempty"`
+}
+
+// Response is written by the plugin to stdout.
+type Response struct {
+	// Error is set if the plugin was unable to handle the request.
+	Error string `json:"error,omitempty"`
+
+	Rules  []Rule  `json:"rules,omitempty"`
+	Issues []Issue `json:"issues,omitempty"`
+}
+
+// Rule describes a rule implemented by a plugin.
+type Rule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// Severity is the default severity of the rule's issues: error, warning,
+	// or info. Empty defaults to error.
+	Severity string `json:"severity,omitempty"`
+}
+
+// Issue is a problem found by a plugin rule.
+type Issue struct {
+	RuleID string `json:"rule_id"`
+	// Resource is the name of the resource or data source the issue was found
+	// in, or empty for issues with the provider as a whole.
+	Resource   string   `json:"resource,omitempty"`
+	DataSource bool     `json:"data_source,omitempty"`
+	Severity   string   `json:"severity,omitempty"`
+	Position   Position `json:"position"`
+	Message    string   `json:"message"`
+
+	AttributePath string `json:"attribute,omitempty"`
+}
+
+// Position is a location in a source file, lines and columns start at 1.
+type Position struct {
+	Filename string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// Provider is the serialized form of a provparse.Provider.
+type Provider struct {
+	Name        string      `json:"name"`
+	Position    Position    `json:"position"`
+	Attributes  []Attribute `json:"attributes,omitempty"`
+	Resources   []Resource  `json:"resources"`
+	DataSources []Resource  `json:"data_sources"`
+}
+
+// Resource is the serialized form of a provparse.Resource.
+type Resource struct {
+	Name     string   `json:"name"`
+	Position Position `json:"position"`
+
+	Create *Function `json:"create,omitempty"`
+	Read   *Function `json:"read,omitempty"`
+	Update *Function `json:"update,omitempty"`
+	Delete *Function `json:"delete,omitempty"`
+	Exists *Function `json:"exists,omitempty"`
+
+	Attributes   []Attribute `json:"attributes,omitempty"`
+	PartialParse bool        `json:"partial_parse,omitempty"`
+}
+
+// Function identifies a function in the provider source.
+type Function struct {
+	Name     string   `json:"name"`
+	Position Position `json:"position"`
+}
+
+// Attribute is the serialized form of a provparse.Attribute.
+type Attribute struct {
+	Name        string   `json:"name"`
+	Position    Position `json:"position"`
+	Description string   `json:"description,omitempty"`
+
+	Optional bool `json:"optional,omitempty"`
+	Required bool `json:"required,omitempty"`
+	Computed bool `json:"computed,omitempty"`
+
+	// Type is the name of the schema type, for example "TypeString".
+	Type string `json:"type"`
+	// ElemType is the name of the schema type of the elements of a collection
+	// of primitives, for example "TypeString" for a list of strings.
+	ElemType string `json:"elem_type,omitempty"`
+
+	Attributes   []Attribute `json:"attributes,omitempty"`
+	PartialParse bool        `json:"partial_parse,omitempty"`
+}
+
+// NewProvider serializes a parsed provider.
+func NewProvider(prov *provparse.Provider) *Provider {
+	return &Provider{
+		Name:        prov.Name,
+		Position:    newPosition(prov.Fset, prov.Pos()),
+		Attributes:  newAttributes(prov.Fset, prov.Attributes),
+		Resources:   newResources(prov.Fset, prov.Resources),
+		DataSources: newResources(prov.Fset, prov.DataSources),
+	}
+}
+
+func newPosition(fset *token.FileSet, pos token.Pos) Position {
+	p := fset.Position(pos)
+	return Position{
+		Filename: p.Filename,
+		Line:     p.Line,
+		Column:   p.Column,
+	}
+}
+
+func newResources(fset *token.FileSet, resources []provparse.Resource) []Resource {
+	serialized := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		serialized = append(serialized, Resource{
+			Name:         r.Name,
+			Position:     newPosition(fset, r.Pos()),
+			Create:       newFunction(fset, r.CreateFunc),
+			Read:         newFunction(fset, r.ReadFunc),
+			Update:       newFunction(fset, r.UpdateFunc),
+			Delete:       newFunction(fset, r.DeleteFunc),
+			Exists:       newFunction(fset, r.ExistsFunc),
+			Attributes:   newAttributes(fset, r.Attributes),
+			PartialParse: r.PartialParse,
+		})
+	}
+	return serialized
+}
+
+func newFunction(fset *token.FileSet, f *ssa.Function) *Function {
+	if f == nil {
+		return nil
+	}
+	return &Function{
+		Name:     f.String(),
+		Position: newPosition(fset, f.Pos()),
+	}
+}
+
+func newAttributes(fset *token.FileSet, atts []provparse.Attribute) []Attribute {
+	if len(atts) == 0 {
+		return nil
+	}
+	serialized := make([]Attribute, 0, len(atts))
+	for _, att := range atts {
+		serialized = append(serialized, Attribute{
+			Name:         att.Name,
+			Position:     newPosition(fset, att.Pos()),
+			Description:  att.Description,
+			Optional:     att.Optional,
+			Required:     att.Required,
+			Computed:     att.Computed,
+			Type:         att.Type.String(),
+			ElemType:     elemTypeName(att.ElemType),
+			Attributes:   newAttributes(fset, att.Attributes),
+			PartialParse: att.PartialParse,
+		})
+	}
+	return serialized
+}
+
+func elemTypeName(t provparse.AttributeType) string {
+	if t == provparse.TypeInvalid {
+		return ""
+	}
+	return t.String()
+}
+
+// TokenPos returns the position in the file set, or token.NoPos if the file is
+// not part of the file set.
+func (p Position) TokenPos(fset *token.FileSet) token.Pos {
+	pos := token.NoPos
+	fset.Iterate(func(f *token.File) bool {
+		if f.Name() != p.Filename {
+			return true
+		}
+		if p.Line >= 1 && p.Line <= f.LineCount() {
+			pos = f.LineStart(p.Line)
+			if p.Column > 1 && int(pos)-f.Base()+p.Column-1 <= f.Size() {
+				pos += token.Pos(p.Column - 1)
+			}
+		}
+		return false
+	})
+	return pos
+}
@@ -0,0 +1,59 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Plugin is implemented by plugins written in Go and passed to Serve.
+type Plugin interface {
+	// Rules returns the rules implemented by the plugin.
+	Rules() []Rule
+	// Check returns the issues found in the provider by the enabled rules.
+	Check(prov *Provider, rules map[string]bool) ([]Issue, error)
+}
+
+// Serve handles a request from tfprovlint on stdin and writes the response to
+// stdout. It is typically the only call in a plugin's main func.
+func Serve(p Plugin) {
+	err := serve(os.Stdin, os.Stdout, p)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func serve(r io.Reader, w io.Writer, p Plugin) error {
+	var req Request
+	err := json.NewDecoder(r).Decode(&req)
+	if err != nil {
+		return fmt.Errorf("unable to decode request: %s", err)
+	}
+
+	resp := &Response{}
+	switch {
+	case req.Version != ProtocolVersion:
+		resp.Error = fmt.Sprintf("unsupported protocol version %d, expected %d", req.Version, ProtocolVersion)
+	case req.Method == MethodRules:
+		resp.Rules = p.Rules()
+	case req.Method == MethodCheck:
+		if req.Provider == nil {
+			resp.Error = "no provider in check request"
+			break
+		}
+		rules := make(map[string]bool, len(req.Rules))
+		for _, id := range req.Rules {
+			rules[id] = true
+		}
+		resp.Issues, err = p.Check(req.Provider, rules)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	default:
+		resp.Error = fmt.Sprintf("unknown method %q", req.Method)
+	}
+
+	return json.NewEncoder(w).Encode(resp)
+}
@@ -3,10 +3,13 @@ package provparse
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/types"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/tools/go/ssa"
 
@@ -16,8 +19,15 @@ import (
 const (
 	pkgTFHelperSchema = "github.com/hashicorp/terraform/helper/schema"
 
-	resourceStructTypeName = "github.com/hashicorp/terraform/helper/schema.Resource"
-	schemaStructTypeName   = "github.com/hashicorp/terraform/helper/schema.Schema"
+	providerStructTypeName         = "github.com/hashicorp/terraform/helper/schema.Provider"
+	resourceStructTypeName         = "github.com/hashicorp/terraform/helper/schema.Resource"
+	schemaStructTypeName           = "github.com/hashicorp/terraform/helper/schema.Schema"
+	valueTypeTypeName              = "github.com/hashicorp/terraform/helper/schema.ValueType"
+	importerStructTypeName         = "github.com/hashicorp/terraform/helper/schema.ResourceImporter"
+	timeoutStructTypeName          = "github.com/hashicorp/terraform/helper/schema.ResourceTimeout"
+	stateUpgraderStructTypeName    = "github.com/hashicorp/terraform/helper/schema.StateUpgrader"
+	funcImportStatePassthroughName = "ImportStatePassthrough"
+	funcDefaultTimeoutName         = "DefaultTimeout"
 )
 
 func (p *provParser) resourceFunc(name string) *ssa.Function {
@@ -42,8 +52,8 @@ func (p *provParser) parse() (*Provider, error) {
 
 	dataSources := make([]Resource, 0, len(dataSourceFuncs))
 
-	for name, fName := range dataSourceFuncs {
-		r, err := p.buildResource(name, p.resourceFunc(fName))
+	for _, name := range sortedKeys(dataSourceFuncs) {
+		r, err := p.buildResource(name, p.resourceFunc(dataSourceFuncs[name]))
 		if err != nil {
 			return nil, err
 		}
@@ -53,8 +63,8 @@ func (p *provParser) parse() (*Provider, error) {
 
 	resources := make([]Resource, 0, len(resourceFuncs))
 
-	for name, fName := range resourceFuncs {
-		r, err := p.buildResource(name, p.resourceFunc(fName))
+	for _, name := range sortedKeys(resourceFuncs) {
+		r, err := p.buildResource(name, p.resourceFunc(resourceFuncs[name]))
 		if err != nil {
 			return nil, err
 		}
@@ -62,13 +72,117 @@ func (p *provParser) parse() (*Provider, error) {
 		resources = append(resources, *r)
 	}
 
-	return &Provider{
+	prov := &Provider{
+		Name:        p.providerName(),
 		DataSources: dataSources,
 		Resources:   resources,
-		Fset:        p.prog.Fset,
+		Fset:        p.fset,
+		Files:       p.files,
 
 		pos: provFunc.Pos(),
-	}, nil
+	}
+
+	err = p.parseProviderFields(prov, provFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return prov, nil
+}
+
+// providerName returns the name of the provider from the closest
+// terraform-provider-x element of the package path, or an empty string if there
+// is none.
+func (p *provParser) providerName() string {
+	pkgPath := ssahelp.NormalizePkgPath(p.pkg.Pkg)
+	for dir := pkgPath; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if name, ok := providerName(path.Base(dir)); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func isPointer(t types.Type) bool {
+	_, ok := t.(*types.Pointer)
+	return ok
+}
+
+// variableRefs returns the references to the values stored in, and loaded
+// from, a variable.
+func variableRefs(v *ssa.Alloc) []ssa.Instruction {
+	var refs []ssa.Instruction
+	for _, ref := range *v.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.Store:
+			if ref.Addr != v {
+				continue
+			}
+			if stored, ok := ssahelp.RootValue(ref.Val).(*ssa.Alloc); ok {
+				refs = append(refs, *stored.Referrers()...)
+			}
+		case *ssa.UnOp:
+			refs = append(refs, *ref.Referrers()...)
+		}
+	}
+	return refs
+}
+
+// parseProviderFields sets the attributes and ConfigureFunc of the provider from
+// the schema.Provider returned by provFunc.
+func (p *provParser) parseProviderFields(prov *Provider, provFunc *ssa.Function) error {
+	retValue := ssahelp.RootValue(ssahelp.ReturnValue(provFunc, 0))
+	alloc, ok := retValue.(*ssa.Alloc)
+	if !ok {
+		p.tracef("expected Alloc for provider but found %T", retValue)
+		prov.PartialParse = true
+		return nil
+	}
+	refs := *alloc.Referrers()
+	if elem := alloc.Type().(*types.Pointer).Elem(); isPointer(elem) {
+		// the provider pointer is in a variable captured by a closure, such as
+		// the ConfigureFunc, so include the references through the variable
+		refs = variableRefs(alloc)
+	}
+
+	f, _, err := ssahelp.StructFieldFuncCallValue(refs, providerStructTypeName, "ConfigureFunc")
+	if ok, err := p.fieldFound(prov, &prov.PartialParse, "ConfigureFunc", err); err != nil {
+		return err
+	} else if ok {
+		prov.ConfigureFunc = f
+	}
+
+	schemaVal, err := ssahelp.StructFieldValue(refs, providerStructTypeName, "Schema")
+	if err != nil {
+		if !ssahelp.IsNoFieldAddrFound(err) {
+			return wrapNodeErrorf(err, provFunc, "unable to find provider schema")
+		}
+		return nil
+	}
+	schemaVal = ssahelp.RootValue(schemaVal)
+	attrs := []Attribute{}
+	partial, err := p.appendAttributes(&attrs, schemaVal)
+	if err != nil {
+		return wrapNodeErrorf(err, schemaVal, "error with provider attributes")
+	}
+	if partial {
+		prov.PartialParse = true
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name < attrs[j].Name
+	})
+	prov.Attributes = attrs
+
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func (p *provParser) extractProviderData(provFunc *ssa.Function) (map[string]string, map[string]string, error) {
@@ -208,6 +322,11 @@ func (p *provParser) buildResource(name string, rf *ssa.Function) (*Resource, er
 		set(f)
 	}
 
+	err := p.parseResourceFields(r, refs)
+	if err != nil {
+		return nil, wrapNodeErrorf(err, rf, "error with fields of %q", name)
+	}
+
 	schemaVal, err := ssahelp.StructFieldValue(refs, resourceStructTypeName, "Schema")
 	if err != nil {
 		if !ssahelp.IsNoFieldAddrFound(err) {
@@ -234,6 +353,186 @@ func (p *provParser) buildResource(name string, rf *ssa.Function) (*Resource, er
 	return r, nil
 }
 
+// parseResourceFields sets the fields of the resource other than its schema and
+// CRUD functions.
+func (p *provParser) parseResourceFields(r *Resource, refs []ssa.Instruction) error {
+	if v, err := ssahelp.StructFieldIntValue(refs, resourceStructTypeName, "SchemaVersion"); err != nil {
+		if _, err := p.fieldFound(r, &r.PartialParse, "SchemaVersion", err); err != nil {
+			return err
+		}
+	} else {
+		r.SchemaVersion = v
+	}
+
+	if v, err := ssahelp.StructFieldStringValue(refs, resourceStructTypeName, "DeprecationMessage"); err != nil {
+		if _, err := p.fieldFound(r, &r.PartialParse, "DeprecationMessage", err); err != nil {
+			return err
+		}
+	} else {
+		r.DeprecationMessage = v
+	}
+
+	for field, set := range map[string]func(*ssa.Function){
+		"CustomizeDiff": func(f *ssa.Function) { r.CustomizeDiffFunc = f },
+		"MigrateState":  func(f *ssa.Function) { r.MigrateStateFunc = f },
+	} {
+		f, _, err := ssahelp.StructFieldFuncCallValue(refs, resourceStructTypeName, field)
+		if ok, err := p.fieldFound(r, &r.PartialParse, field, err); err != nil {
+			return err
+		} else if ok {
+			set(f)
+		}
+	}
+
+	for field, parse := range map[string]func(ssa.Value) error{
+		"Importer":       func(v ssa.Value) error { return p.parseImporter(r, v) },
+		"Timeouts":       func(v ssa.Value) error { return p.parseTimeouts(r, v) },
+		"StateUpgraders": func(v ssa.Value) error { return p.parseStateUpgraders(r, v) },
+	} {
+		v, err := ssahelp.StructFieldValue(refs, resourceStructTypeName, field)
+		if ok, err := p.fieldFound(r, &r.PartialParse, field, err); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		v = ssahelp.RootValue(v)
+		if c, ok := v.(*ssa.Const); ok && c.IsNil() {
+			continue
+		}
+		err = parse(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *provParser) parseImporter(r *Resource, v ssa.Value) error {
+	r.Importer = &Importer{
+		pos: v.Pos(),
+	}
+	alloc, ok := v.(*ssa.Alloc)
+	if !ok {
+		p.tracef("expected Alloc for Importer but found %T", v)
+		r.PartialParse = true
+		return nil
+	}
+
+	f, _, err := ssahelp.StructFieldFuncCallValue(*alloc.Referrers(), importerStructTypeName, "State")
+	if ok, err := p.fieldFound(r.Importer, &r.PartialParse, "State", err); err != nil {
+		return err
+	} else if ok && f != nil {
+		r.Importer.StateFunc = f
+		r.Importer.Passthrough = f.Pkg != nil && f.Name() == funcImportStatePassthroughName &&
+			ssahelp.NormalizePkgPath(f.Pkg.Pkg) == pkgTFHelperSchema
+	}
+	return nil
+}
+
+func (p *provParser) parseTimeouts(r *Resource, v ssa.Value) error {
+	r.Timeouts = &Timeouts{}
+	alloc, ok := v.(*ssa.Alloc)
+	if !ok {
+		p.tracef("expected Alloc for Timeouts but found %T", v)
+		r.PartialParse = true
+		return nil
+	}
+
+	for field, set := range map[string]func(*time.Duration){
+		"Create":  func(d *time.Duration) { r.Timeouts.Create = d },
+		"Read":    func(d *time.Duration) { r.Timeouts.Read = d },
+		"Update":  func(d *time.Duration) { r.Timeouts.Update = d },
+		"Delete":  func(d *time.Duration) { r.Timeouts.Delete = d },
+		"Default": func(d *time.Duration) { r.Timeouts.Default = d },
+	} {
+		v, err := ssahelp.StructFieldValue(*alloc.Referrers(), timeoutStructTypeName, field)
+		if ok, err := p.fieldFound(r, &r.PartialParse, field, err); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		d, ok := defaultTimeoutValue(v)
+		if !ok {
+			p.tracef("unable to determine %s timeout from %T", field, v)
+			r.PartialParse = true
+			continue
+		}
+		set(d)
+	}
+	return nil
+}
+
+// defaultTimeoutValue returns the duration passed to schema.DefaultTimeout.
+func defaultTimeoutValue(v ssa.Value) (*time.Duration, bool) {
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return nil, false
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Name() != funcDefaultTimeoutName || len(call.Call.Args) != 1 {
+		return nil, false
+	}
+	c, ok := ssahelp.RootValue(call.Call.Args[0]).(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.Int {
+		return nil, false
+	}
+	d := time.Duration(c.Int64())
+	return &d, true
+}
+
+func (p *provParser) parseStateUpgraders(r *Resource, v ssa.Value) error {
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		p.tracef("expected Slice for StateUpgraders but found %T", v)
+		r.PartialParse = true
+		return nil
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		p.tracef("expected Alloc for StateUpgraders but found %T", slice.X)
+		r.PartialParse = true
+		return nil
+	}
+	arr, ok := ssahelp.DerefType(alloc.Type()).Underlying().(*types.Array)
+	if !ok {
+		return nodeErrorf(alloc, "unexpected StateUpgraders type %s", alloc.Type())
+	}
+
+	upgraders := make([]StateUpgrader, arr.Len())
+	for _, ref := range *alloc.Referrers() {
+		indexAddr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		index, ok := indexAddr.Index.(*ssa.Const)
+		if !ok {
+			r.PartialParse = true
+			continue
+		}
+		upgrader := &upgraders[index.Int64()]
+		upgrader.pos = indexAddr.Pos()
+		elemRefs := *indexAddr.Referrers()
+
+		if version, err := ssahelp.StructFieldIntValue(elemRefs, stateUpgraderStructTypeName, "Version"); err != nil {
+			if _, err := p.fieldFound(r, &r.PartialParse, "Version", err); err != nil {
+				return err
+			}
+		} else {
+			upgrader.Version = version
+		}
+
+		f, _, err := ssahelp.StructFieldFuncCallValue(elemRefs, stateUpgraderStructTypeName, "Upgrade")
+		if ok, err := p.fieldFound(r, &r.PartialParse, "Upgrade", err); err != nil {
+			return err
+		} else if ok {
+			upgrader.UpgradeFunc = f
+		}
+	}
+	r.StateUpgraders = upgraders
+	return nil
+}
+
 func (p *provParser) appendAttributes(attrs *[]Attribute, schemaVal ssa.Value) (bool, error) {
 	switch v := schemaVal.(type) {
 	case *ssa.Alloc:
@@ -310,6 +609,23 @@ func (p *provParser) appendAttributes(attrs *[]Attribute, schemaVal ssa.Value) (
 	return partial, nil
 }
 
+// fieldFound handles the error looking up the value of a struct field,
+// returning true if the value was found. partial is set if the field is set to
+// a value that cannot be determined.
+func (p *provParser) fieldFound(node poser, partial *bool, field string, err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case ssahelp.IsNoFieldAddrFound(err):
+		return false, nil
+	case ssahelp.IsNoExpectedValueFound(err):
+		p.tracef("unexpected value found for %s: %s", field, err.Error())
+		*partial = true
+		return false, nil
+	}
+	return false, wrapNodeErrorf(err, node, "unable to determine %s value", field)
+}
+
 func (p *provParser) buildAttribute(name string, v ssa.Value) (Attribute, error) {
 	refs := *v.Referrers()
 	att := Attribute{
@@ -318,39 +634,88 @@ func (p *provParser) buildAttribute(name string, v ssa.Value) (Attribute, error)
 
 		pos: v.Pos(),
 	}
-	if v, err := ssahelp.StructFieldStringValue(refs, schemaStructTypeName, "Description"); err != nil {
-		switch {
-		case ssahelp.IsNoFieldAddrFound(err):
-			p.tracef("no description found")
-		case ssahelp.IsNoExpectedValueFound(err):
-			p.tracef("unexpected value found for %q Description: %s", name, err.Error())
-			att.PartialParse = true
-		default:
-			return Attribute{}, wrapNodeErrorf(err, &att, "unable to determine description")
+	for field, set := range map[string]func(string){
+		"Description":  func(v string) { att.Description = v },
+		"Deprecated":   func(v string) { att.Deprecated = v },
+		"Removed":      func(v string) { att.Removed = v },
+		"InputDefault": func(v string) { att.InputDefault = v },
+	} {
+		v, err := ssahelp.StructFieldStringValue(refs, schemaStructTypeName, field)
+		if ok, err := p.fieldFound(&att, &att.PartialParse, field, err); err != nil {
+			return Attribute{}, err
+		} else if ok {
+			set(v)
 		}
-	} else {
-		att.Description = v
 	}
 
 	for field, set := range map[string]func(bool){
-		"Required": func(v bool) { att.Required = v },
-		"Computed": func(v bool) { att.Computed = v },
-		"Optional": func(v bool) { att.Optional = v },
+		"Required":      func(v bool) { att.Required = v },
+		"Computed":      func(v bool) { att.Computed = v },
+		"Optional":      func(v bool) { att.Optional = v },
+		"ForceNew":      func(v bool) { att.ForceNew = v },
+		"Sensitive":     func(v bool) { att.Sensitive = v },
+		"PromoteSingle": func(v bool) { att.PromoteSingle = v },
 	} {
 		v, err := ssahelp.StructFieldBoolValue(refs, schemaStructTypeName, field)
-		if err != nil {
-			switch {
-			case ssahelp.IsNoFieldAddrFound(err):
-				continue
-			case ssahelp.IsNoExpectedValueFound(err):
-				p.tracef("unexpected value found for %q %s: %s", name, field, err.Error())
-				att.PartialParse = true
-				continue
-			default:
-				return Attribute{}, wrapNodeErrorf(err, &att, "unable to determine bool value for %q", field)
-			}
+		if ok, err := p.fieldFound(&att, &att.PartialParse, field, err); err != nil {
+			return Attribute{}, err
+		} else if ok {
+			set(v)
+		}
+	}
+
+	for field, set := range map[string]func(int){
+		"MinItems": func(v int) { att.MinItems = v },
+		"MaxItems": func(v int) { att.MaxItems = v },
+	} {
+		v, err := ssahelp.StructFieldIntValue(refs, schemaStructTypeName, field)
+		if ok, err := p.fieldFound(&att, &att.PartialParse, field, err); err != nil {
+			return Attribute{}, err
+		} else if ok {
+			set(v)
+		}
+	}
+
+	for field, set := range map[string]func([]string){
+		"ConflictsWith": func(v []string) { att.ConflictsWith = v },
+		"ComputedWhen":  func(v []string) { att.ComputedWhen = v },
+	} {
+		v, err := ssahelp.StructFieldStringSliceValue(refs, schemaStructTypeName, field)
+		if ok, err := p.fieldFound(&att, &att.PartialParse, field, err); err != nil {
+			return Attribute{}, err
+		} else if ok {
+			set(v)
+		}
+	}
+
+	for field, set := range map[string]func(*ssa.Function, []ssa.Value){
+		"ValidateFunc":     func(f *ssa.Function, args []ssa.Value) { att.ValidateFunc, att.ValidateFuncArgs = f, args },
+		"DiffSuppressFunc": func(f *ssa.Function, args []ssa.Value) { att.DiffSuppressFunc, att.DiffSuppressFuncArgs = f, args },
+		"StateFunc":        func(f *ssa.Function, args []ssa.Value) { att.StateFunc, att.StateFuncArgs = f, args },
+		"Set":              func(f *ssa.Function, args []ssa.Value) { att.SetFunc, att.SetFuncArgs = f, args },
+	} {
+		f, args, err := ssahelp.StructFieldFuncCallValue(refs, schemaStructTypeName, field)
+		if ok, err := p.fieldFound(&att, &att.PartialParse, field, err); err != nil {
+			return Attribute{}, err
+		} else if ok {
+			set(f, args)
 		}
-		set(v)
+	}
+
+	if defaultVal, err := ssahelp.StructFieldValue(refs, schemaStructTypeName, "Default"); err == nil {
+		defaultVal = ssahelp.RootValue(defaultVal)
+		if c, ok := defaultVal.(*ssa.Const); !ok || !c.IsNil() {
+			att.Default = defaultVal
+		}
+	} else if !ssahelp.IsNoFieldAddrFound(err) {
+		return Attribute{}, wrapNodeErrorf(err, &att, "unable to determine Default value")
+	}
+
+	if defaultFuncVal, err := ssahelp.StructFieldValue(refs, schemaStructTypeName, "DefaultFunc"); err == nil {
+		c, ok := ssahelp.RootValue(defaultFuncVal).(*ssa.Const)
+		att.HasDefaultFunc = !ok || !c.IsNil()
+	} else if !ssahelp.IsNoFieldAddrFound(err) {
+		return Attribute{}, wrapNodeErrorf(err, &att, "unable to determine DefaultFunc value")
 	}
 
 	typeVal, err := ssahelp.StructFieldValue(refs, schemaStructTypeName, "Type")
@@ -368,34 +733,14 @@ func (p *provParser) buildAttribute(name string, v ssa.Value) (Attribute, error)
 			return Attribute{}, wrapNodeErrorf(err, v, "unable to extract Schema.Type for attribute %q", name)
 		}
 	} else {
-		typeVal = ssahelp.RootValue(typeVal)
-		cst, ok := typeVal.(*ssa.Const)
-		if !ok {
-			return Attribute{}, nodeErrorf(typeVal, "unable to find Type const %T", typeVal)
-		}
-
-		switch AttributeType(cst.Int64()) {
-		case TypeBool:
-			att.Type = TypeBool
-		case TypeInt:
-			att.Type = TypeInt
-		case TypeFloat:
-			att.Type = TypeFloat
-		case TypeString:
-			att.Type = TypeString
-		case TypeList:
-			att.Type = TypeList
-		case TypeMap:
-			att.Type = TypeMap
-		case TypeSet:
-			att.Type = TypeSet
-		default:
-			return Attribute{}, nodeErrorf(cst, "unexpected type %q", cst.Value.ExactString())
+		att.Type, err = attributeType(ssahelp.RootValue(typeVal))
+		if err != nil {
+			return Attribute{}, err
 		}
 	}
 
 	childrenFieldName := "Schema"
-	if att.Type == TypeList || att.Type == TypeSet {
+	if att.Type == TypeList || att.Type == TypeSet || att.Type == TypeMap {
 		childrenFieldName = "Elem"
 	}
 
@@ -409,6 +754,18 @@ func (p *provParser) buildAttribute(name string, v ssa.Value) (Attribute, error)
 
 	if schemaVal != nil {
 		schemaVal = ssahelp.RootValue(schemaVal)
+		elemType, ok, err := p.elemType(schemaVal)
+		if err != nil {
+			return Attribute{}, wrapNodeErrorf(err, schemaVal, "error with Elem type for %q", name)
+		}
+		if ok {
+			att.ElemType = elemType
+			if elemType == TypeNotParsed {
+				att.PartialParse = true
+			}
+			return att, nil
+		}
+
 		attrs := []Attribute{}
 		partial, err := p.appendAttributes(&attrs, schemaVal)
 		if err != nil {
@@ -425,3 +782,46 @@ func (p *provParser) buildAttribute(name string, v ssa.Value) (Attribute, error)
 
 	return att, nil
 }
+
+// attributeType returns the type for a schema.ValueType constant.
+func attributeType(v ssa.Value) (AttributeType, error) {
+	cst, ok := v.(*ssa.Const)
+	if !ok {
+		return TypeInvalid, nodeErrorf(v, "unable to find Type const %T", v)
+	}
+
+	switch t := AttributeType(cst.Int64()); t {
+	case TypeBool, TypeInt, TypeFloat, TypeString, TypeList, TypeMap, TypeSet:
+		return t, nil
+	}
+	return TypeInvalid, nodeErrorf(cst, "unexpected type %q", cst.Value.ExactString())
+}
+
+// elemType returns the type of the elements of a collection if its Elem is a
+// *schema.Schema, or a schema.ValueType as allowed by older versions of the
+// SDK. ok is false if the Elem is not a primitive type, such as a nested
+// *schema.Resource.
+func (p *provParser) elemType(v ssa.Value) (AttributeType, bool, error) {
+	switch v := v.(type) {
+	case *ssa.Alloc:
+		if !ssahelp.TypeMatch(ssahelp.DerefType(v.Type()), schemaStructTypeName) {
+			return TypeInvalid, false, nil
+		}
+		typeVal, err := ssahelp.StructFieldValue(*v.Referrers(), schemaStructTypeName, "Type")
+		if err != nil {
+			if !ssahelp.IsNoFieldAddrFound(err) {
+				return TypeInvalid, false, err
+			}
+			p.tracef("unable to find Elem Type: %s", err.Error())
+			return TypeNotParsed, true, nil
+		}
+		t, err := attributeType(ssahelp.RootValue(typeVal))
+		return t, true, err
+	case *ssa.Const:
+		if ssahelp.TypeMatch(v.Type(), valueTypeTypeName) {
+			t, err := attributeType(v)
+			return t, true, err
+		}
+	}
+	return TypeInvalid, false, nil
+}
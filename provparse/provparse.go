@@ -2,8 +2,10 @@ package provparse
 
 import (
 	"fmt"
+	"go/ast"
 	"go/build"
 	"go/parser"
+	"go/token"
 	"go/types"
 	"log"
 	"os"
@@ -15,8 +17,9 @@ import (
 )
 
 type provParser struct {
-	prog *loader.Program
-	pkg  *ssa.Package
+	fset  *token.FileSet
+	pkg   *ssa.Package
+	files []*ast.File
 }
 
 var (
@@ -102,15 +105,23 @@ func Package(path string) (*Provider, error) {
 		return nil, fmt.Errorf("provider package not found")
 	}
 
+	//only one non-test package in the path
+	return SSAPackage(pkg, prog.Package(loadedPath).Files)
+}
+
+// SSAPackage parses a provider from a package that has already been built, for
+// example by the go/analysis buildssa pass. files are the parsed source files,
+// including comments, of the package.
+func SSAPackage(pkg *ssa.Package, files []*ast.File) (*Provider, error) {
 	p := &provParser{
-		prog: prog,
-		pkg:  pkg,
+		fset:  pkg.Prog.Fset,
+		pkg:   pkg,
+		files: files,
 	}
 
-	//only one non-test package in the path
 	prov, err := p.parse()
 	if err != nil {
-		return nil, unwrapError(err, prog.Fset)
+		return nil, unwrapError(err, p.fset)
 	}
 	return prov, nil
 }
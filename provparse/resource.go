@@ -1,7 +1,10 @@
 package provparse
 
 import (
+	"go/ast"
 	"go/token"
+	"strings"
+	"time"
 
 	"golang.org/x/tools/go/ssa"
 )
@@ -14,6 +17,15 @@ type Provider struct {
 	DataSources []Resource
 	Fset        *token.FileSet
 
+	ConfigureFunc *ssa.Function
+
+	// PartialParse indicates that there is a high probability the full details
+	// of the provider itself were not read.
+	PartialParse bool
+
+	// Files are the parsed source files, including comments, of the provider package.
+	Files []*ast.File
+
 	pos token.Pos
 }
 
@@ -47,6 +59,19 @@ type Resource struct {
 	DeleteFunc *ssa.Function
 	ExistsFunc *ssa.Function
 
+	// Importer is nil if the resource does not support import.
+	Importer *Importer
+	// Timeouts is nil if no default timeouts are set.
+	Timeouts *Timeouts
+
+	CustomizeDiffFunc *ssa.Function
+	MigrateStateFunc  *ssa.Function
+
+	SchemaVersion  int
+	StateUpgraders []StateUpgrader
+
+	DeprecationMessage string
+
 	Attributes []Attribute
 
 	// PartialParse indicates that there is a high probability the full details were not read
@@ -57,6 +82,35 @@ type Resource struct {
 	pos token.Pos
 }
 
+// Importer represents the import support of a resource.
+type Importer struct {
+	// StateFunc is the State function of the importer, nil if it could not be
+	// determined.
+	StateFunc *ssa.Function
+	// Passthrough is true if StateFunc is schema.ImportStatePassthrough.
+	Passthrough bool
+
+	pos token.Pos
+}
+
+// Timeouts are the default timeouts of a resource, a nil duration is not set.
+type Timeouts struct {
+	Create  *time.Duration
+	Read    *time.Duration
+	Update  *time.Duration
+	Delete  *time.Duration
+	Default *time.Duration
+}
+
+// StateUpgrader represents an upgrade of the resource state from a schema
+// version.
+type StateUpgrader struct {
+	Version     int
+	UpgradeFunc *ssa.Function
+
+	pos token.Pos
+}
+
 func findAttribute(atts []Attribute, name string) *Attribute {
 	for _, att := range atts {
 		if att.Name == name {
@@ -91,7 +145,44 @@ type Attribute struct {
 	Required bool
 	Computed bool
 
+	ForceNew  bool
+	Sensitive bool
+
 	Type AttributeType
+	// ElemType is the type of the elements of a TypeList, TypeSet, or TypeMap
+	// with a primitive Elem. It is TypeInvalid for other types and for nested
+	// blocks, whose Elem is a *schema.Resource (see Attributes).
+	ElemType AttributeType
+
+	// Default is the value of the Default field, or nil if it is not set.
+	Default ssa.Value
+	// HasDefaultFunc is true if the DefaultFunc field is set.
+	HasDefaultFunc bool
+
+	Deprecated   string
+	Removed      string
+	InputDefault string
+
+	MinItems int
+	MaxItems int
+
+	ConflictsWith []string
+	ComputedWhen  []string
+
+	PromoteSingle bool
+
+	// ValidateFunc, DiffSuppressFunc, StateFunc, and SetFunc (the Set hash
+	// function) are the functions assigned to the schema fields. If the function
+	// was returned by a call, such as validation.StringInSlice, the arguments of
+	// the call are recorded in the matching Args field.
+	ValidateFunc         *ssa.Function
+	ValidateFuncArgs     []ssa.Value
+	DiffSuppressFunc     *ssa.Function
+	DiffSuppressFuncArgs []ssa.Value
+	StateFunc            *ssa.Function
+	StateFuncArgs        []ssa.Value
+	SetFunc              *ssa.Function
+	SetFuncArgs          []ssa.Value
 
 	Attributes []Attribute
 
@@ -117,6 +208,16 @@ const (
 	TypeNotParsed AttributeType = -1
 )
 
+// TypeName returns the type of the attribute in lower case, with the element
+// type of collections of primitives, for example "list(string)".
+func (a *Attribute) TypeName() string {
+	name := strings.ToLower(strings.TrimPrefix(a.Type.String(), "Type"))
+	if a.ElemType != TypeInvalid {
+		name += "(" + strings.ToLower(strings.TrimPrefix(a.ElemType.String(), "Type")) + ")"
+	}
+	return name
+}
+
 // Pos returns the location of the AST token most closely associated.
 func (p *Provider) Pos() token.Pos {
 	return p.pos
@@ -131,3 +232,13 @@ func (r *Resource) Pos() token.Pos {
 func (a *Attribute) Pos() token.Pos {
 	return a.pos
 }
+
+// Pos returns the location of the AST token most closely associated.
+func (i *Importer) Pos() token.Pos {
+	return i.pos
+}
+
+// Pos returns the location of the AST token most closely associated.
+func (u *StateUpgrader) Pos() token.Pos {
+	return u.pos
+}
@@ -0,0 +1,704 @@
+package provparse_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// schemaStubSrc is a minimal stub of the helper/schema package.
+const schemaStubSrc = `
+package schema
+
+import "time"
+
+type ValueType int
+
+const (
+	TypeInvalid ValueType = iota
+	TypeBool
+	TypeInt
+	TypeFloat
+	TypeString
+	TypeList
+	TypeMap
+	TypeSet
+)
+
+type SchemaDefaultFunc func() (interface{}, error)
+type SchemaValidateFunc func(interface{}, string) ([]string, []error)
+type SchemaDiffSuppressFunc func(k, old, new string, d *ResourceData) bool
+type SchemaStateFunc func(interface{}) string
+type SchemaSetFunc func(interface{}) int
+
+type Schema struct {
+	Type          ValueType
+	Optional      bool
+	Required      bool
+	Computed      bool
+	ForceNew      bool
+	Sensitive     bool
+	Description   string
+	InputDefault  string
+	Default       interface{}
+	DefaultFunc   SchemaDefaultFunc
+	Elem          interface{}
+	MaxItems      int
+	MinItems      int
+	PromoteSingle bool
+	ComputedWhen  []string
+	ConflictsWith []string
+	Deprecated    string
+	Removed       string
+
+	ValidateFunc     SchemaValidateFunc
+	DiffSuppressFunc SchemaDiffSuppressFunc
+	StateFunc        SchemaStateFunc
+	Set              SchemaSetFunc
+}
+
+func HashString(v interface{}) int { return 0 }
+
+type ResourceData struct{}
+
+func (d *ResourceData) Id() string { return "" }
+
+func (d *ResourceData) SetId(string) {}
+
+func (d *ResourceData) Set(string, interface{}) error { return nil }
+
+type CreateFunc func(*ResourceData, interface{}) error
+type ReadFunc func(*ResourceData, interface{}) error
+type UpdateFunc func(*ResourceData, interface{}) error
+type DeleteFunc func(*ResourceData, interface{}) error
+type ExistsFunc func(*ResourceData, interface{}) (bool, error)
+
+type Resource struct {
+	Schema map[string]*Schema
+	Create CreateFunc
+	Read   ReadFunc
+	Update UpdateFunc
+	Delete DeleteFunc
+	Exists ExistsFunc
+
+	Importer           *ResourceImporter
+	Timeouts           *ResourceTimeout
+	CustomizeDiff      CustomizeDiffFunc
+	SchemaVersion      int
+	MigrateState       StateMigrateFunc
+	StateUpgraders     []StateUpgrader
+	DeprecationMessage string
+}
+
+type ResourceDiff struct{}
+
+type CustomizeDiffFunc func(*ResourceDiff, interface{}) error
+
+type InstanceState struct{}
+
+type StateMigrateFunc func(int, *InstanceState, interface{}) (*InstanceState, error)
+
+type StateUpgradeFunc func(map[string]interface{}, interface{}) (map[string]interface{}, error)
+
+type StateUpgrader struct {
+	Version int
+	Type    interface{}
+	Upgrade StateUpgradeFunc
+}
+
+type StateFunc func(*ResourceData, interface{}) ([]*ResourceData, error)
+
+type ResourceImporter struct {
+	State StateFunc
+}
+
+func ImportStatePassthrough(d *ResourceData, m interface{}) ([]*ResourceData, error) {
+	return []*ResourceData{d}, nil
+}
+
+type ResourceTimeout struct {
+	Create, Read, Update, Delete, Default *time.Duration
+}
+
+func DefaultTimeout(tx interface{}) *time.Duration {
+	d := tx.(time.Duration)
+	return &d
+}
+
+type ConfigureFunc func(*ResourceData) (interface{}, error)
+
+type Provider struct {
+	Schema         map[string]*Schema
+	ResourcesMap   map[string]*Resource
+	DataSourcesMap map[string]*Resource
+	ConfigureFunc  ConfigureFunc
+}
+
+func EnvDefaultFunc(k string, dv interface{}) SchemaDefaultFunc {
+	return func() (interface{}, error) { return dv, nil }
+}
+`
+
+const pkgSchema = "github.com/hashicorp/terraform/helper/schema"
+
+// parseSource parses a provider from the source of a single file package that
+// may import the schema stub.
+func parseSource(t *testing.T, src string) *provparse.Provider {
+	t.Helper()
+	return parsePackageSource(t, "github.com/terraform-providers/terraform-provider-test/test", "test", src)
+}
+
+// parsePackageSource is parseSource with the package path and name.
+func parsePackageSource(t *testing.T, pkgPath, name, src string) *provparse.Provider {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	schemaFile, err := parser.ParseFile(fset, "schema.go", schemaStubSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaPkg, err := (&types.Config{Importer: importer.Default()}).Check(pkgSchema, fset, []*ast.File{schemaFile}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parser.ParseFile(fset, "provider.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []*ast.File{f}
+	conf := &types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == pkgSchema {
+				return schemaPkg, nil
+			}
+			return importer.Default().Import(path)
+		}),
+	}
+	pkg, _, err := ssautil.BuildPackage(conf, fset, types.NewPackage(pkgPath, name), files, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prov, err := provparse.SSAPackage(pkg, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prov
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+func TestSSAPackage_schemaFields(t *testing.T) {
+	prov := parseSource(t, `
+package test
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_thing": resourceTestThing(),
+		},
+	}
+}
+
+var dynamicConflicts = []string{"name"}
+
+func resourceTestThing() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:          schema.TypeString,
+				Required:      true,
+				ForceNew:      true,
+				Sensitive:     true,
+				Deprecated:    "use title",
+				InputDefault:  "thing",
+				ConflictsWith: []string{"title", "tags"},
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "untitled",
+				Removed:     "removed in 2.0",
+				ComputedWhen: []string{},
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TEST_REGION", nil),
+			},
+			"tags": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				MaxItems:      10,
+				PromoteSingle: true,
+				Default:       nil,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+			},
+			"dynamic": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: dynamicConflicts,
+			},
+		},
+	}
+}
+`)
+
+	r := prov.Resource("test_thing")
+	if r == nil {
+		t.Fatal("expected resource test_thing")
+	}
+
+	name := r.Attribute("name")
+	if !name.Required || !name.ForceNew || !name.Sensitive || name.Deprecated != "use title" || name.InputDefault != "thing" {
+		t.Fatalf("unexpected name attribute %#v", name)
+	}
+	if expected := []string{"title", "tags"}; !reflect.DeepEqual(expected, name.ConflictsWith) {
+		t.Fatalf("unexpected ConflictsWith %q", name.ConflictsWith)
+	}
+	if name.Default != nil || name.HasDefaultFunc || name.PartialParse {
+		t.Fatalf("unexpected name attribute %#v", name)
+	}
+
+	title := r.Attribute("title")
+	if title.Default == nil || title.Removed != "removed in 2.0" || title.ComputedWhen == nil || len(title.ComputedWhen) != 0 {
+		t.Fatalf("unexpected title attribute %#v", title)
+	}
+
+	if region := r.Attribute("region"); !region.HasDefaultFunc || region.Default != nil {
+		t.Fatalf("unexpected region attribute %#v", region)
+	}
+
+	tags := r.Attribute("tags")
+	if tags.MinItems != 1 || tags.MaxItems != 10 || !tags.PromoteSingle || tags.Default != nil {
+		t.Fatalf("unexpected tags attribute %#v", tags)
+	}
+
+	dynamic := r.Attribute("dynamic")
+	if !dynamic.PartialParse || dynamic.ConflictsWith != nil {
+		t.Fatalf("expected dynamic to be partially parsed %#v", dynamic)
+	}
+}
+
+func TestSSAPackage_schemaFieldReassigned(t *testing.T) {
+	prov := parseSource(t, `
+package test
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_thing": resourceTestThing(),
+		},
+	}
+}
+
+func resourceTestThing() *schema.Resource {
+	name := &schema.Schema{Type: schema.TypeString, Optional: true}
+	name.Optional = false
+	name.Required = true
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": name,
+		},
+	}
+}
+`)
+
+	name := prov.Resource("test_thing").Attribute("name")
+	if name == nil || name.Optional || !name.Required {
+		t.Fatalf("expected the last values stored to be used, found %#v", name)
+	}
+}
+
+func TestSSAPackage_schemaFuncs(t *testing.T) {
+	prov := parseSource(t, `
+package test
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_thing": resourceTestThing(),
+		},
+	}
+}
+
+func stringInSlice(valid []string, ignoreCase bool) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		return nil, nil
+	}
+}
+
+func validateName(v interface{}, k string) ([]string, []error) {
+	return nil, nil
+}
+
+func suppressCase(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func resourceTestThing() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateName,
+				DiffSuppressFunc: suppressCase,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"size": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: stringInSlice([]string{"small", "large"}, true),
+				StateFunc:    nil,
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+`)
+
+	r := prov.Resource("test_thing")
+	if r == nil {
+		t.Fatal("expected resource test_thing")
+	}
+
+	name := r.Attribute("name")
+	if name.ValidateFunc == nil || name.ValidateFunc.Name() != "validateName" || name.ValidateFuncArgs != nil {
+		t.Fatalf("unexpected ValidateFunc %v", name.ValidateFunc)
+	}
+	if name.DiffSuppressFunc == nil || name.DiffSuppressFunc.Name() != "suppressCase" {
+		t.Fatalf("unexpected DiffSuppressFunc %v", name.DiffSuppressFunc)
+	}
+	if name.StateFunc == nil || name.StateFunc.Parent() == nil {
+		t.Fatalf("expected StateFunc to be the anonymous function, found %v", name.StateFunc)
+	}
+
+	size := r.Attribute("size")
+	if size.ValidateFunc == nil || size.ValidateFunc.Parent() == nil || size.ValidateFunc.Parent().Name() != "stringInSlice" {
+		t.Fatalf("expected ValidateFunc to be the closure returned by stringInSlice, found %v", size.ValidateFunc)
+	}
+	if len(size.ValidateFuncArgs) != 2 {
+		t.Fatalf("expected the stringInSlice arguments, found %v", size.ValidateFuncArgs)
+	}
+	if size.StateFunc != nil || size.PartialParse {
+		t.Fatalf("unexpected size attribute %#v", size)
+	}
+
+	tags := r.Attribute("tags")
+	if tags.SetFunc == nil || tags.SetFunc.Name() != "HashString" {
+		t.Fatalf("unexpected Set func %v", tags.SetFunc)
+	}
+}
+
+func TestSSAPackage_resourceFields(t *testing.T) {
+	prov := parseSource(t, `
+package test
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_thing":  resourceTestThing(),
+			"test_legacy": resourceTestLegacy(),
+		},
+	}
+}
+
+func resourceTestThing() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(time.Hour),
+		},
+		CustomizeDiff: customizeDiff,
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{Version: 0, Upgrade: upgradeV0},
+			{Version: 1, Upgrade: upgradeV1},
+		},
+
+		Schema: map[string]*schema.Schema{},
+	}
+}
+
+func resourceTestLegacy() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLegacy,
+		},
+		MigrateState:       migrateState,
+		SchemaVersion:      1,
+		DeprecationMessage: "use test_thing",
+
+		Schema: map[string]*schema.Schema{},
+	}
+}
+
+func customizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return nil
+}
+
+func upgradeV0(s map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return s, nil
+}
+
+func upgradeV1(s map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return s, nil
+}
+
+func importLegacy(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, nil
+}
+
+func migrateState(v int, is *schema.InstanceState, meta interface{}) (*schema.InstanceState, error) {
+	return is, nil
+}
+`)
+
+	thing := prov.Resource("test_thing")
+	if thing == nil {
+		t.Fatal("expected resource test_thing")
+	}
+	if thing.PartialParse {
+		t.Fatal("expected test_thing to be fully parsed")
+	}
+	if thing.Importer == nil || !thing.Importer.Passthrough {
+		t.Fatalf("expected passthrough importer, found %#v", thing.Importer)
+	}
+	if thing.Timeouts == nil || thing.Timeouts.Create == nil || *thing.Timeouts.Create != 10*time.Minute ||
+		thing.Timeouts.Delete == nil || *thing.Timeouts.Delete != time.Hour || thing.Timeouts.Read != nil {
+		t.Fatalf("unexpected timeouts %#v", thing.Timeouts)
+	}
+	if thing.CustomizeDiffFunc == nil || thing.CustomizeDiffFunc.Name() != "customizeDiff" {
+		t.Fatalf("unexpected CustomizeDiff %v", thing.CustomizeDiffFunc)
+	}
+	if thing.SchemaVersion != 2 || thing.MigrateStateFunc != nil {
+		t.Fatalf("unexpected state versioning %#v", thing)
+	}
+	if len(thing.StateUpgraders) != 2 {
+		t.Fatalf("expected 2 state upgraders, found %d", len(thing.StateUpgraders))
+	}
+	for i, u := range thing.StateUpgraders {
+		if u.Version != i || u.UpgradeFunc == nil || u.UpgradeFunc.Name() != fmt.Sprintf("upgradeV%d", i) {
+			t.Fatalf("unexpected state upgrader %d %#v", i, u)
+		}
+	}
+
+	legacy := prov.Resource("test_legacy")
+	if legacy == nil {
+		t.Fatal("expected resource test_legacy")
+	}
+	if legacy.Importer == nil || legacy.Importer.Passthrough || legacy.Importer.StateFunc == nil || legacy.Importer.StateFunc.Name() != "importLegacy" {
+		t.Fatalf("unexpected importer %#v", legacy.Importer)
+	}
+	if legacy.Timeouts != nil || legacy.CustomizeDiffFunc != nil || legacy.StateUpgraders != nil {
+		t.Fatalf("unexpected fields set %#v", legacy)
+	}
+	if legacy.MigrateStateFunc == nil || legacy.SchemaVersion != 1 || legacy.DeprecationMessage != "use test_thing" {
+		t.Fatalf("unexpected legacy fields %#v", legacy)
+	}
+}
+
+func TestSSAPackage_provider(t *testing.T) {
+	prov := parseSource(t, `
+package test
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() interface{} {
+	p := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{},
+	}
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return p, nil
+	}
+	return p
+}
+`)
+
+	if prov.Name != "test" {
+		t.Fatalf("unexpected provider name %q", prov.Name)
+	}
+	if prov.PartialParse {
+		t.Fatal("expected the provider to be fully parsed")
+	}
+	if token := prov.Attribute("token"); token == nil || !token.Required || !token.Sensitive {
+		t.Fatalf("unexpected token attribute %#v", token)
+	}
+	if endpoints := prov.Attribute("endpoints"); endpoints == nil || endpoints.Attribute("api") == nil {
+		t.Fatalf("unexpected endpoints attribute %#v", endpoints)
+	}
+	if prov.ConfigureFunc == nil || prov.ConfigureFunc.Parent() == nil || prov.ConfigureFunc.Parent().Name() != "Provider" {
+		t.Fatalf("expected ConfigureFunc to be the closure in Provider, found %v", prov.ConfigureFunc)
+	}
+}
+
+func TestSSAPackage_providerNoName(t *testing.T) {
+	prov := parsePackageSource(t, "github.com/acme/cloud/acmecloud", "acme", `
+package acme
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{},
+	}
+}
+`)
+
+	if prov.Name != "" {
+		t.Fatalf("expected no provider name outside of a terraform-provider-x path, found %q", prov.Name)
+	}
+}
+
+func TestSSAPackage_elemTypes(t *testing.T) {
+	prov := parseSource(t, `
+package test
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"test_thing": resourceTestThing(),
+		},
+	}
+}
+
+func resourceTestThing() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ports": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     schema.TypeString,
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {Type: schema.TypeString, Required: true},
+					},
+				},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+`)
+
+	r := prov.Resource("test_thing")
+	if r == nil {
+		t.Fatal("expected resource test_thing")
+	}
+
+	for _, c := range []struct {
+		name     string
+		elemType provparse.AttributeType
+		typeName string
+	}{
+		{"names", provparse.TypeString, "list(string)"},
+		{"ports", provparse.TypeInt, "set(int)"},
+		{"tags", provparse.TypeString, "map(string)"},
+		{"labels", provparse.TypeInvalid, "map"},
+		{"rule", provparse.TypeInvalid, "list"},
+		{"name", provparse.TypeInvalid, "string"},
+	} {
+		att := r.Attribute(c.name)
+		if att == nil {
+			t.Fatalf("expected attribute %q", c.name)
+		}
+		if att.ElemType != c.elemType {
+			t.Errorf("expected %q to have elem type %v, found %v", c.name, c.elemType, att.ElemType)
+		}
+		if name := att.TypeName(); name != c.typeName {
+			t.Errorf("expected %q to have type name %q, found %q", c.name, c.typeName, name)
+		}
+		if att.PartialParse {
+			t.Errorf("expected %q to be fully parsed", c.name)
+		}
+	}
+
+	if rule := r.Attribute("rule"); rule.Attribute("action") == nil {
+		t.Fatal("expected nested block attributes for rule")
+	}
+}
@@ -1,8 +1,6 @@
 package rules
 
 import (
-	"go/token"
-
 	"golang.org/x/tools/go/ssa"
 
 	"github.com/paultyng/tfprovlint/lint"
@@ -16,6 +14,12 @@ type callBlacklistRule struct {
 	IssueMessageFormat string
 	RuleID             string
 
+	// SuggestFixes optionally returns fixes for a blacklisted call.
+	SuggestFixes func(ssacall ssa.CallInstruction) []lint.SuggestedFix
+	// FixDirectCallsOnly limits fixes to calls made by the resource function
+	// itself, or its anonymous functions, and not by helpers it calls.
+	FixDirectCallsOnly bool
+
 	Create map[string]bool
 	Read   map[string]bool
 	Exists map[string]bool
@@ -25,6 +29,31 @@ type callBlacklistRule struct {
 
 var _ lint.ResourceRule = &callBlacklistRule{}
 
+// CallList is a list of function names for each resource function. Functions
+// are named by package path, like "time.Sleep", and methods by receiver type,
+// like "(*net/http.Client).Get".
+type CallList struct {
+	Create []string
+	Read   []string
+	Update []string
+	Delete []string
+	Exists []string
+}
+
+// NewCallBlacklistRule returns a rule that reports calls to the listed
+// functions from the resource functions, or any function they call. The issue
+// message is formatted with the name of the function called.
+func NewCallBlacklistRule(issueMessageFormat string, calls CallList) lint.ResourceRule {
+	return &callBlacklistRule{
+		IssueMessageFormat: issueMessageFormat,
+		Create:             stringSliceToSet(calls.Create),
+		Read:               stringSliceToSet(calls.Read),
+		Update:             stringSliceToSet(calls.Update),
+		Delete:             stringSliceToSet(calls.Delete),
+		Exists:             stringSliceToSet(calls.Exists),
+	}
+}
+
 func (rule *callBlacklistRule) CheckResource(readOnly bool, r *provparse.Resource) ([]lint.Issue, error) {
 	var issues []lint.Issue
 
@@ -46,9 +75,13 @@ func (rule *callBlacklistRule) CheckResource(readOnly bool, r *provparse.Resourc
 
 			if calls := rule.functionCalls(t.f, t.blacklist); len(calls) > 0 {
 				// it makes some of the calls, need to append issues
-				for call, positions := range calls {
-					for _, pos := range positions {
-						issues = append(issues, lint.NewIssuef(pos, rule.IssueMessageFormat, call))
+				for call, ssacalls := range calls {
+					for _, ssacall := range ssacalls {
+						iss := lint.NewIssuef(ssacall.Pos(), rule.IssueMessageFormat, call)
+						if rule.SuggestFixes != nil && (!rule.FixDirectCallsOnly || calledFrom(ssacall, t.f)) {
+							iss.Fixes = rule.SuggestFixes(ssacall)
+						}
+						issues = append(issues, iss)
 					}
 				}
 			}
@@ -58,8 +91,21 @@ func (rule *callBlacklistRule) CheckResource(readOnly bool, r *provparse.Resourc
 	return issues, nil
 }
 
-func (rule *callBlacklistRule) functionCalls(f *ssa.Function, callList map[string]bool) map[string][]token.Pos {
-	calls := map[string][]token.Pos{}
+// calledFrom returns true if the call is made by f, or an anonymous function
+// declared within it.
+func calledFrom(ssacall ssa.CallInstruction, f *ssa.Function) bool {
+	for fn := ssacall.Parent(); fn != nil; fn = fn.Parent() {
+		if fn == f {
+			return true
+		}
+	}
+	return false
+}
+
+// functionCalls returns the calls to functions in callList made by f, or any
+// function it calls, by function name.
+func (rule *commonRule) functionCalls(f *ssa.Function, callList map[string]bool) map[string][]ssa.CallInstruction {
+	calls := map[string][]ssa.CallInstruction{}
 
 	ssahelp.InspectInstructions(ssahelp.FuncInstructions(f), func(ins ssa.Instruction) bool {
 		ssacall, ok := ins.(ssa.CallInstruction)
@@ -71,7 +117,7 @@ func (rule *callBlacklistRule) functionCalls(f *ssa.Function, callList map[strin
 			calleeName := normalizeSSAFunctionString(callee)
 			rule.tracef("checking %q against list", calleeName)
 			if callList[calleeName] {
-				calls[calleeName] = append(calls[calleeName], ssacall.Pos())
+				calls[calleeName] = append(calls[calleeName], ssacall)
 			}
 		}
 
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+
+	"github.com/paultyng/tfprovlint/provparse"
 )
 
 func TestFunctionCalls(t *testing.T) {
@@ -65,6 +67,23 @@ func TestFunctionCalls(t *testing.T) {
 
 }
 
+func TestNewCallBlacklistRule(t *testing.T) {
+	rule := NewCallBlacklistRule("do not call %s", CallList{
+		Read:   []string{"fmt.Println"},
+		Delete: []string{"test.baz"},
+	})
+	r := &provparse.Resource{
+		ReadFunc:   functionCallsPkg.Func("foo"),
+		DeleteFunc: functionCallsPkg.Func("baz"),
+	}
+
+	issues, err := rule.CheckResource(false, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertIssueMsg(t, "do not call fmt.Println", issues)
+}
+
 var functionCallsPkg = mustMakeSamplePkg(`
 package test
 
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"sort"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// callRequiredRule is the counterpart of callBlacklistRule, it reports
+// resource functions that do not call a function, either directly or in any
+// function they call.
+type callRequiredRule struct {
+	commonRule
+
+	IssueMessageFormat string
+
+	Create map[string]bool
+	Read   map[string]bool
+	Exists map[string]bool
+	Update map[string]bool
+	Delete map[string]bool
+
+	// SkipResource optionally skips resources the rule does not apply to.
+	SkipResource func(readOnly bool, r *provparse.Resource) bool
+}
+
+var _ lint.ResourceRule = &callRequiredRule{}
+
+// NewCallRequiredRule returns a rule that reports resource functions that do
+// not call the listed functions. The issue message is formatted with the name
+// of the function that is not called.
+func NewCallRequiredRule(issueMessageFormat string, calls CallList) lint.ResourceRule {
+	return &callRequiredRule{
+		IssueMessageFormat: issueMessageFormat,
+		Create:             stringSliceToSet(calls.Create),
+		Read:               stringSliceToSet(calls.Read),
+		Update:             stringSliceToSet(calls.Update),
+		Delete:             stringSliceToSet(calls.Delete),
+		Exists:             stringSliceToSet(calls.Exists),
+	}
+}
+
+func (rule *callRequiredRule) CheckResource(readOnly bool, r *provparse.Resource) ([]lint.Issue, error) {
+	if rule.SkipResource != nil && rule.SkipResource(readOnly, r) {
+		return nil, nil
+	}
+
+	var issues []lint.Issue
+
+	for _, t := range []struct {
+		required map[string]bool
+		f        *ssa.Function
+	}{
+		{rule.Create, r.CreateFunc},
+		{rule.Read, r.ReadFunc},
+		{rule.Exists, r.ExistsFunc},
+		{rule.Update, r.UpdateFunc},
+		{rule.Delete, r.DeleteFunc},
+	} {
+		if t.f == nil || len(t.required) == 0 {
+			continue
+		}
+		if t.f.Blocks == nil {
+			// the function body is not available, for example if it is in
+			// another package, so its calls are unknown
+			rule.tracef("skipping %s with no body", t.f.Name())
+			continue
+		}
+		if funcName := normalizeSSAFunctionString(t.f); funcName == funcRemoveFromState {
+			continue
+		}
+
+		calls := rule.functionCalls(t.f, t.required)
+		missing := make([]string, 0, len(t.required))
+		for name := range t.required {
+			if len(calls[name]) == 0 {
+				missing = append(missing, name)
+			}
+		}
+		sort.Strings(missing)
+		for _, name := range missing {
+			issues = append(issues, lint.NewIssuef(t.f.Pos(), rule.IssueMessageFormat, name))
+		}
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+func TestNewCallRequiredRule(t *testing.T) {
+	for i, c := range []struct {
+		expectedMsg string
+		calls       CallList
+	}{
+		// finds nested calls
+		{"", CallList{Create: []string{"test.baz", "(*bytes.Buffer).Len"}}},
+		{"", CallList{Read: []string{"fmt.Println"}}},
+		{"must call fmt.Printf", CallList{Create: []string{"test.baz", "fmt.Printf"}}},
+		{"must call fmt.Println", CallList{Delete: []string{"fmt.Println"}}},
+		// functions not set are skipped
+		{"", CallList{Update: []string{"fmt.Println"}}},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			rule := NewCallRequiredRule("must call %s", c.calls)
+			r := &provparse.Resource{
+				CreateFunc: functionCallsPkg.Func("bar"),
+				ReadFunc:   functionCallsPkg.Func("foo"),
+				DeleteFunc: functionCallsPkg.Func("baz"),
+			}
+
+			issues, err := rule.CheckResource(false, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertIssueMsg(t, c.expectedMsg, issues)
+		})
+	}
+}
+
+func TestSetInReadFuncRule(t *testing.T) {
+	rule := NewSetInReadFuncRule()
+	r := &provparse.Resource{
+		ReadFunc: functionCallsPkg.Func("foo"),
+	}
+
+	issues, err := rule.CheckResource(false, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertIssueMsg(t, "", issues)
+
+	r.Attributes = []provparse.Attribute{{Name: "name"}}
+	issues, err = rule.CheckResource(false, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertIssueMsg(t, fmt.Sprintf("ReadFunc should call %s", funcResourceDataSet), issues)
+}
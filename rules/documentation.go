@@ -0,0 +1,16 @@
+package rules
+
+// Documentation is the long form explanation of a rule.
+type Documentation struct {
+	// Rationale explains why the rule exists.
+	Rationale string
+
+	// Bad is example code that the rule reports.
+	Bad string
+
+	// Good is the example code corrected.
+	Good string
+
+	// FalsePositives describes known patterns that are incorrectly reported.
+	FalsePositives string
+}
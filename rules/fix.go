@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// callSyntax returns the syntax of the call instruction and, if the call is
+// used as a statement on its own, the enclosing statement. A nil call is
+// returned if the syntax cannot be found, for example if the function was
+// synthesized.
+func callSyntax(ssacall ssa.CallInstruction) (*ast.CallExpr, *ast.ExprStmt) {
+	fn := ssacall.Parent()
+	if fn == nil || fn.Syntax() == nil || !ssacall.Pos().IsValid() {
+		return nil, nil
+	}
+
+	// for ordinary calls the position of the instruction is the Lparen
+	lparen := ssacall.Pos()
+	var (
+		call *ast.CallExpr
+		stmt *ast.ExprStmt
+	)
+	ast.Inspect(fn.Syntax(), func(n ast.Node) bool {
+		if call != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			if c, ok := astutil.Unparen(n.X).(*ast.CallExpr); ok && c.Lparen == lparen {
+				call = c
+				stmt = n
+			}
+		case *ast.CallExpr:
+			if n.Lparen == lparen {
+				call = n
+			}
+		}
+		return true
+	})
+	return call, stmt
+}
@@ -0,0 +1,157 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"sort"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+// applyFixes applies the edits of the first fix of each issue to the source of
+// the file containing them.
+func applyFixes(t *testing.T, fset *token.FileSet, src string, issues []lint.Issue) string {
+	t.Helper()
+
+	var edits []lint.TextEdit
+	for _, iss := range issues {
+		if len(iss.Fixes) > 0 {
+			edits = append(edits, iss.Fixes[0].Edits...)
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos > edits[j].Pos })
+
+	out := []byte(src)
+	for _, e := range edits {
+		start, end := fset.Position(e.Pos).Offset, fset.Position(e.End).Offset
+		out = append(out[:start:start], append(e.NewText, out[end:]...)...)
+	}
+	return string(out)
+}
+
+func findCalls(pkg *ssa.Package, funcName, calleeName string) []ssa.CallInstruction {
+	r := &callBlacklistRule{}
+	return r.functionCalls(pkg.Func(funcName), map[string]bool{calleeName: true})[calleeName]
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	for i, c := range []struct {
+		expected string
+		funcName string
+		callee   string
+		fix      func(ssa.CallInstruction) []lint.SuggestedFix
+	}{
+		{"\td.SetId(\"x\")", "deleteSetIdValue", "(*test.ResourceData).SetId", removeSetIdStmt},
+		{"\t", "deleteSetIdEmpty", "(*test.ResourceData).SetId", removeSetIdStmt},
+		{"\treturn fmt.Errorf(\"error %%d: %s\", err)", "wrapf", "test.Wrapf", replaceErrwrapWrapf},
+		{"\treturn fmt.Errorf(`error: %s`, err)", "wrapfRaw", "test.Wrapf", replaceErrwrapWrapf},
+		{"\treturn Wrapf(\"error\", err)", "wrapfNoErr", "test.Wrapf", replaceErrwrapWrapf},
+		{"\treturn Wrapf(format, err)", "wrapfVar", "test.Wrapf", replaceErrwrapWrapf},
+	} {
+		t.Run(fmt.Sprintf("%d %s", i, c.funcName), func(t *testing.T) {
+			calls := findCalls(fixPkg, c.funcName, c.callee)
+			if len(calls) != 1 {
+				t.Fatalf("expected a single call, found %d", len(calls))
+			}
+			iss := lint.Issue{Pos: calls[0].Pos(), Fixes: c.fix(calls[0])}
+			actual := applyFixes(t, fixPkg.Prog.Fset, fixSrc, []lint.Issue{iss})
+
+			fn := fixPkg.Func(c.funcName)
+			start := fixPkg.Prog.Fset.Position(fn.Syntax().Pos()).Offset
+			actual = actual[start:]
+			if !bytes.Contains([]byte(actual), []byte(c.expected+"\n}")) {
+				t.Fatalf("expected %q in:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNoSetIdInDeleteFuncFixes(t *testing.T) {
+	rule := &callBlacklistRule{
+		IssueMessageFormat: "DeleteFunc should not call %s",
+		Delete:             map[string]bool{"(*test.ResourceData).SetId": true},
+		SuggestFixes:       removeSetIdStmt,
+		FixDirectCallsOnly: true,
+	}
+	r := &provparse.Resource{
+		ReadFunc:   fixPkg.Func("readSharedClearId"),
+		DeleteFunc: fixPkg.Func("deleteSharedClearId"),
+	}
+
+	issues, err := rule.CheckResource(false, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, found %d", len(issues))
+	}
+
+	actual := applyFixes(t, fixPkg.Prog.Fset, fixSrc, issues)
+	for _, expected := range []string{
+		"func clearId(d *ResourceData) {\n\td.SetId(\"\")\n}",
+		"func deleteSharedClearId(d *ResourceData) {\n\tclearId(d)\n\tfunc() {\n\t\t\n\t}()\n\t\n}",
+	} {
+		if !bytes.Contains([]byte(actual), []byte(expected)) {
+			t.Fatalf("expected %q in:\n%s", expected, actual)
+		}
+	}
+}
+
+const fixSrc = `package test
+
+type ResourceData struct{}
+
+func (d *ResourceData) SetId(string) {}
+
+func Wrapf(string, error) error { return nil }
+
+func deleteSetIdValue(d *ResourceData) {
+	d.SetId("x")
+}
+
+func deleteSetIdEmpty(d *ResourceData) {
+	d.SetId("")
+}
+
+// clearId is shared by Read and Delete, the call is only fixed in Delete
+func clearId(d *ResourceData) {
+	d.SetId("")
+}
+
+func readSharedClearId(d *ResourceData) {
+	clearId(d)
+}
+
+func deleteSharedClearId(d *ResourceData) {
+	clearId(d)
+	func() {
+		d.SetId("")
+	}()
+	d.SetId("")
+}
+
+func wrapf(err error) error {
+	return Wrapf("error %d: {{err}}", err)
+}
+
+func wrapfRaw(err error) error {
+	return Wrapf(` + "`error: {{err}}`" + `, err)
+}
+
+func wrapfNoErr(err error) error {
+	return Wrapf("error", err)
+}
+
+const format = "error: {{err}}"
+
+func wrapfVar(err error) error {
+	return Wrapf(format, err)
+}
+`
+
+var fixPkg = mustMakeSamplePkg(fixSrc)
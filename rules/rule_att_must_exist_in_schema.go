@@ -1,12 +1,31 @@
 package rules
 
 import (
+	"fmt"
+
 	"golang.org/x/tools/go/ssa"
 
 	"github.com/paultyng/tfprovlint/lint"
 	"github.com/paultyng/tfprovlint/provparse"
 )
 
+var SetAttributeNameExistsRuleDoc = Documentation{
+	Rationale: `Calling d.Set with an attribute that is not in the resource schema returns an
+error and the value is discarded. The error is frequently ignored, so typos
+or attributes removed from the schema go unnoticed and the state is silently
+missing data.`,
+	Bad: `Schema: map[string]*schema.Schema{
+	"name": {Type: schema.TypeString, Required: true},
+},
+
+d.Set("nmae", thing.Name)`,
+	Good: `d.Set("name", thing.Name)`,
+	FalsePositives: `The schema is read statically from the source, so attributes added dynamically
+(for example in a loop, by merging maps returned from helper functions, or in
+DataSourceResourceShim) may not be found and are reported. Suppress these
+with a //tfprovlint:ignore comment.`,
+}
+
 func NewSetAttributeNameExistsRule() lint.ResourceRule {
 	return &resourceDataSetRule{
 		CheckAttributeSet: setAttributeNameExists,
@@ -15,9 +34,14 @@ func NewSetAttributeNameExistsRule() lint.ResourceRule {
 
 func setAttributeNameExists(r *provparse.Resource, att *provparse.Attribute, attName string, ssacall ssa.CallInstruction) ([]lint.Issue, error) {
 	if att == nil {
-		return []lint.Issue{
-			lint.NewIssuef(ssacall.Pos(), "attribute %q was not read from the schema", attName),
-		}, nil
+		iss := lint.NewIssuef(ssacall.Pos(), "attribute %q was not read from the schema", attName)
+		iss.AttributePath = attName
+		if r != nil {
+			iss.Related = []lint.RelatedLocation{
+				{Pos: r.Pos(), Message: fmt.Sprintf("schema of %q is defined here", r.Name)},
+			}
+		}
+		return []lint.Issue{iss}, nil
 	}
 	return nil, nil
 }
@@ -1,8 +1,10 @@
 package rules
 
 import (
+	"go/ast"
 	"go/token"
 
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/ssa"
 
 	"github.com/paultyng/tfprovlint/lint"
@@ -10,6 +12,14 @@ import (
 	"github.com/paultyng/tfprovlint/ssahelp"
 )
 
+var DoNotDereferencePointersInSetRuleDoc = Documentation{
+	Rationale: `d.Set accepts pointers to primitive values and writes the zero value when the
+pointer is nil. Dereferencing the pointer before calling d.Set panics when an
+API omits the field in its response, crashing the provider instead.`,
+	Bad:  `d.Set("description", *out.Description)`,
+	Good: `d.Set("description", out.Description)`,
+}
+
 func NewDoNotDereferencePointersInSetRule() lint.ResourceRule {
 	return &resourceDataSetRule{
 		CheckAttributeSet: doNotDereferencePointersInSet,
@@ -36,9 +46,10 @@ func doNotDereferencePointersInSet(r *provparse.Resource, att *provparse.Attribu
 				}
 
 				if stars := numStars(v.X.Type()); stars > 0 {
-					return []lint.Issue{
-						lint.NewIssuef(ssacall.Pos(), "do not dereference value for attribute %q when calling d.Set", attName),
-					}, nil
+					iss := lint.NewIssuef(ssacall.Pos(), "do not dereference value for attribute %q when calling d.Set", attName)
+					iss.AttributePath = attName
+					iss.Fixes = removeSetDereference(ssacall, v)
+					return []lint.Issue{iss}, nil
 				}
 			}
 		}
@@ -46,3 +57,25 @@ func doNotDereferencePointersInSet(r *provparse.Resource, att *provparse.Attribu
 
 	return issues, nil
 }
+
+// removeSetDereference suggests removing the dereference if it is the value
+// argument of the d.Set call, for example d.Set("foo", *v).
+func removeSetDereference(ssacall ssa.CallInstruction, deref *ssa.UnOp) []lint.SuggestedFix {
+	call, _ := callSyntax(ssacall)
+	if call == nil || len(call.Args) != 2 {
+		return nil
+	}
+	star, ok := astutil.Unparen(call.Args[1]).(*ast.StarExpr)
+	if !ok || star.Star != deref.Pos() {
+		return nil
+	}
+
+	return []lint.SuggestedFix{
+		{
+			Message: "Remove the dereference",
+			Edits: []lint.TextEdit{
+				{Pos: star.Star, End: star.X.Pos()},
+			},
+		},
+	}
+}
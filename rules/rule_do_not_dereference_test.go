@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -46,7 +47,39 @@ func TestDoNotDereferencePointersInSet(t *testing.T) {
 	}
 }
 
-var doNotDereferencePkg = mustMakeSamplePkg(`
+func TestDoNotDereferencePointersInSet_fixes(t *testing.T) {
+	for i, c := range []struct {
+		expected string
+		funcName string
+	}{
+		{`d.Set("foo", v2)`, "setDereference"},
+		{`d.Set("foo", lookupStringPointer())`, "setCallPointerStringDereference"},
+		{`d.Set("foo", instance.PtrString)`, "setReferenceDereference"},
+		{`d.Set("foo", sl[0])`, "setFromSliceDereference"},
+	} {
+		t.Run(fmt.Sprintf("%d %s", i, c.funcName), func(t *testing.T) {
+			ci := lookupSetCallInstruction(doNotDereferencePkg, c.funcName)
+			if ci == nil {
+				t.Fatalf("unable to find ssa.CallInstruction %q", c.funcName)
+			}
+			issues, err := doNotDereferencePointersInSet(nil, nil, "foo", ci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != 1 || len(issues[0].Fixes) != 1 {
+				t.Fatalf("expected a single issue with a fix")
+			}
+			actual := applyFixes(t, doNotDereferencePkg.Prog.Fset, doNotDereferenceSrc, issues)
+			if !strings.Contains(actual, c.expected) {
+				t.Fatalf("expected %q in fixed source", c.expected)
+			}
+		})
+	}
+}
+
+var doNotDereferencePkg = mustMakeSamplePkg(doNotDereferenceSrc)
+
+const doNotDereferenceSrc = `
 package test
 
 type ResourceData struct {}
@@ -166,4 +199,4 @@ func setFromMapDereference(d *ResourceData) {
 	m := map[int]*string{}
 	d.Set("foo", *m[1])
 }
-`)
+`
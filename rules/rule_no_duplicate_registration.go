@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"go/token"
+	"sort"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+var NoDuplicateRegistrationRuleDoc = Documentation{
+	Rationale: `Registering the same resource function under multiple names creates separate
+resource types that share a schema and implementation. This is usually a copy
+and paste mistake, and when intentional the duplicate names should be
+deprecated in favor of a single type.`,
+	Bad: `ResourcesMap: map[string]*schema.Resource{
+	"example_thing":  resourceExampleThing(),
+	"example_widget": resourceExampleThing(),
+},`,
+	Good: `ResourcesMap: map[string]*schema.Resource{
+	"example_thing":  resourceExampleThing(),
+	"example_widget": resourceExampleWidget(),
+},`,
+	FalsePositives: `Resources that are intentionally aliased, for example when renaming a
+resource while keeping the old name for compatibility, are reported.`,
+}
+
+type noDuplicateRegistrationRule struct {
+	commonRule
+}
+
+func NewNoDuplicateRegistrationRule() lint.ProviderRule {
+	return &noDuplicateRegistrationRule{}
+}
+
+func (rule *noDuplicateRegistrationRule) CheckProvider(p *provparse.Provider) ([]lint.Issue, error) {
+	var issues []lint.Issue
+	for _, t := range []struct {
+		kind      string
+		resources []provparse.Resource
+	}{
+		{"data source", p.DataSources},
+		{"resource", p.Resources},
+	} {
+		// the position of a resource is the function that returns it
+		names := map[token.Pos][]string{}
+		for _, r := range t.resources {
+			if r.Pos() == token.NoPos {
+				continue
+			}
+			names[r.Pos()] = append(names[r.Pos()], r.Name)
+		}
+
+		for pos, dupes := range names {
+			if len(dupes) < 2 {
+				continue
+			}
+			sort.Strings(dupes)
+			for _, name := range dupes[1:] {
+				issues = append(issues, lint.NewIssuef(pos, "%s %q is registered with the same function as %q", t.kind, name, dupes[0]))
+			}
+		}
+	}
+
+	return issues, nil
+}
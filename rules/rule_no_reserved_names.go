@@ -35,25 +35,53 @@ type noReservedNamesRule struct {
 	commonRule
 }
 
+var NoReservedNamesRuleDoc = Documentation{
+	Rationale: `Terraform reserves some names in resource and data source blocks for meta
+arguments, such as count, depends_on, lifecycle, and provider, and uses id for
+the resource identifier. Attributes with these names conflict with the
+configuration language and cannot be set by practitioners. Similarly alias
+and version are reserved in provider blocks.`,
+	Bad: `Schema: map[string]*schema.Schema{
+	"count": {Type: schema.TypeInt, Optional: true},
+},`,
+	Good: `Schema: map[string]*schema.Schema{
+	"instance_count": {Type: schema.TypeInt, Optional: true},
+},`,
+}
+
 func NewNoReservedNamesRule() lint.ResourceRule {
 	return &noReservedNamesRule{}
 }
 
+func NewNoReservedProviderNamesRule() lint.ProviderRule {
+	return &noReservedNamesRule{}
+}
+
 func (rule *noReservedNamesRule) CheckResource(readOnly bool, r *provparse.Resource) ([]lint.Issue, error) {
 	fields := reservedResourceFields
 	if readOnly {
 		fields = reservedDataSourceFields
 	}
+	return reservedNameIssues(fields, r.Attributes), nil
+}
+
+func (rule *noReservedNamesRule) CheckProvider(p *provparse.Provider) ([]lint.Issue, error) {
+	return reservedNameIssues(reservedProviderFields, p.Attributes), nil
+}
+
+func reservedNameIssues(fields []string, atts []provparse.Attribute) []lint.Issue {
 	fieldMap := make(map[string]bool, len(fields))
 	for _, f := range fields {
 		fieldMap[f] = true
 	}
 
 	issues := make([]lint.Issue, 0)
-	for _, att := range r.Attributes {
+	for _, att := range atts {
 		if fieldMap[att.Name] {
-			issues = append(issues, lint.NewIssuef(att.Pos(), "%q is a reserved attribute name", att.Name))
+			iss := lint.NewIssuef(att.Pos(), "%q is a reserved attribute name", att.Name)
+			iss.AttributePath = att.Name
+			issues = append(issues, iss)
 		}
 	}
-	return issues, nil
+	return issues
 }
@@ -1,6 +1,31 @@
 package rules
 
-import "github.com/paultyng/tfprovlint/lint"
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+var NoSetIdInDeleteFuncRuleDoc = Documentation{
+	Rationale: `When a DeleteFunc returns without an error the SDK removes the resource from
+state, so calling d.SetId("") is unnecessary. The call is often copied from
+Read functions, where it is used to signal that a resource no longer exists,
+and obscures the intent of the Delete function.`,
+	Bad: `func resourceExampleDelete(d *schema.ResourceData, meta interface{}) error {
+	err := client.Delete(d.Id())
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}`,
+	Good: `func resourceExampleDelete(d *schema.ResourceData, meta interface{}) error {
+	return client.Delete(d.Id())
+}`,
+}
 
 func NewNoSetIdInDeleteFuncRule() lint.ResourceRule {
 	deleteBlacklist := map[string]bool{}
@@ -9,5 +34,28 @@ func NewNoSetIdInDeleteFuncRule() lint.ResourceRule {
 	return &callBlacklistRule{
 		IssueMessageFormat: "DeleteFunc should not call %s",
 		Delete:             deleteBlacklist,
+		SuggestFixes:       removeSetIdStmt,
+		// helpers may be shared with Read, where the call is still needed
+		FixDirectCallsOnly: true,
+	}
+}
+
+// removeSetIdStmt suggests deleting a d.SetId("") statement.
+func removeSetIdStmt(ssacall ssa.CallInstruction) []lint.SuggestedFix {
+	call, stmt := callSyntax(ssacall)
+	if stmt == nil || len(call.Args) != 1 {
+		return nil
+	}
+	if lit, ok := call.Args[0].(*ast.BasicLit); !ok || lit.Kind != token.STRING || (lit.Value != `""` && lit.Value != "``") {
+		return nil
+	}
+
+	return []lint.SuggestedFix{
+		{
+			Message: `Remove the d.SetId("") call`,
+			Edits: []lint.TextEdit{
+				{Pos: stmt.Pos(), End: stmt.End()},
+			},
+		},
 	}
 }
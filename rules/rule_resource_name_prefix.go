@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+var ResourceNamePrefixRuleDoc = Documentation{
+	Rationale: `Terraform determines which provider a resource or data source belongs to from
+the prefix of its type name, everything before the first underscore. Names
+without the provider's prefix require an explicit provider argument in every
+block that uses them.`,
+	Bad: `ResourcesMap: map[string]*schema.Resource{
+	"aws_instance": resourceAwsInstance(),
+	"ec2_volume":   resourceAwsEbsVolume(),
+},`,
+	Good: `ResourcesMap: map[string]*schema.Resource{
+	"aws_instance":   resourceAwsInstance(),
+	"aws_ebs_volume": resourceAwsEbsVolume(),
+},`,
+}
+
+type resourceNamePrefixRule struct {
+	commonRule
+}
+
+func NewResourceNamePrefixRule() lint.ProviderRule {
+	return &resourceNamePrefixRule{}
+}
+
+func (rule *resourceNamePrefixRule) CheckProvider(p *provparse.Provider) ([]lint.Issue, error) {
+	// the name from the package path is not always the prefix used, for
+	// example terraform-provider-google-beta uses "google_"
+	name := commonResourceNamePrefix(p)
+	if name == "" {
+		name = p.Name
+	}
+	if name == "" {
+		rule.warnf("unable to determine the provider name to check resource prefixes")
+		return nil, nil
+	}
+	prefix := name + "_"
+
+	var issues []lint.Issue
+	for _, t := range []struct {
+		kind      string
+		resources []provparse.Resource
+	}{
+		{"data source", p.DataSources},
+		{"resource", p.Resources},
+	} {
+		for _, r := range t.resources {
+			if !strings.HasPrefix(r.Name, prefix) {
+				issues = append(issues, lint.NewIssuef(r.Pos(), "%s %q should start with the provider name prefix %q", t.kind, r.Name, prefix))
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// commonResourceNamePrefix returns the most common prefix (before the first
+// underscore) of the provider's resource and data source names, ties are
+// resolved in favor of the provider name.
+func commonResourceNamePrefix(p *provparse.Provider) string {
+	counts := map[string]int{}
+	for _, resources := range [][]provparse.Resource{p.DataSources, p.Resources} {
+		for _, r := range resources {
+			if i := strings.Index(r.Name, "_"); i > 0 {
+				counts[r.Name[:i]]++
+			}
+		}
+	}
+
+	common := ""
+	for prefix, count := range counts {
+		if count > counts[common] || (count == counts[common] && common != p.Name && (prefix == p.Name || prefix < common)) {
+			common = prefix
+		}
+	}
+	return common
+}
@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+func TestResourceNamePrefix(t *testing.T) {
+	resources := func(names ...string) []provparse.Resource {
+		var rs []provparse.Resource
+		for _, n := range names {
+			rs = append(rs, provparse.Resource{Name: n})
+		}
+		return rs
+	}
+
+	for i, c := range []struct {
+		expectedMsg string
+		prov        *provparse.Provider
+	}{
+		{"", &provparse.Provider{}},
+		{"", &provparse.Provider{
+			Name:        "aws",
+			Resources:   resources("aws_instance", "aws_vpc"),
+			DataSources: resources("aws_ami"),
+		}},
+		{"resource \"ec2_volume\" should start with the provider name prefix \"aws_\"", &provparse.Provider{
+			Name:        "aws",
+			Resources:   resources("aws_instance", "ec2_volume"),
+			DataSources: resources("aws_ami"),
+		}},
+		{"data source \"awsami\" should start with the provider name prefix \"aws_\"", &provparse.Provider{
+			Name:        "aws",
+			DataSources: resources("awsami"),
+		}},
+		// the inferred prefix is preferred over the provider name
+		{"resource \"google-beta_thing\" should start with the provider name prefix \"google_\"", &provparse.Provider{
+			Name:        "google-beta",
+			Resources:   resources("google_compute_instance", "google-beta_thing"),
+			DataSources: resources("google_project"),
+		}},
+		// ties are resolved in favor of the provider name
+		{"resource \"ec2_volume\" should start with the provider name prefix \"zz_\"", &provparse.Provider{
+			Name:      "zz",
+			Resources: resources("zz_instance", "ec2_volume"),
+		}},
+		// the prefix is inferred from the names if the provider name is unknown
+		{"resource \"ec2_volume\" should start with the provider name prefix \"aws_\"", &provparse.Provider{
+			Resources:   resources("aws_instance", "ec2_volume"),
+			DataSources: resources("aws_ami"),
+		}},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			actualIssues, err := NewResourceNamePrefixRule().CheckProvider(c.prov)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertIssueMsg(t, c.expectedMsg, actualIssues)
+		})
+	}
+}
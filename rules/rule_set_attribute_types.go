@@ -35,6 +35,19 @@ var allowedBasicKind = map[provparse.AttributeType][]types.BasicKind{
 	},
 }
 
+var UseProperAttributeTypesInSetRuleDoc = Documentation{
+	Rationale: `d.Set converts the value to the attribute's schema type when writing it to
+state. Values of an incompatible Go type fail the conversion, d.Set returns an
+error, and the attribute is left empty. As the error is often unchecked, the
+resource appears to work but produces perpetual diffs.`,
+	Bad: `"port": {Type: schema.TypeInt, Optional: true},
+
+d.Set("port", strconv.Itoa(thing.Port))`,
+	Good: `d.Set("port", thing.Port)`,
+	FalsePositives: `Setting the result of d.Get (or other interface{} values) of the correct
+underlying type may be reported as the type cannot be determined statically.`,
+}
+
 func NewUseProperAttributeTypesInSetRule() lint.ResourceRule {
 	r := &resourceDataSetRule{}
 
@@ -51,11 +64,23 @@ func useProperAttributeTypesInSet(rule *resourceDataSetRule) func(r *provparse.R
 			return nil, nil
 		}
 
+		attType := att.Type.String()
+		if att.ElemType != provparse.TypeInvalid {
+			attType = fmt.Sprintf("%v of %v", att.Type, att.ElemType)
+		}
+
 		var wrongType = func() ([]lint.Issue, error) {
 			return []lint.Issue{
 				{
-					Pos:     ssacall.Pos(),
-					Message: fmt.Sprintf("attribute %q expects a d.Set compatible with %v", attName, att.Type),
+					Pos:           ssacall.Pos(),
+					Message:       fmt.Sprintf("attribute %q expects a d.Set compatible with %s", attName, attType),
+					AttributePath: attName,
+					Related: []lint.RelatedLocation{
+						{
+							Pos:     att.Pos(),
+							Message: fmt.Sprintf("attribute %q is defined as %s here", attName, attType),
+						},
+					},
 				},
 			}, nil
 		}
@@ -72,36 +97,73 @@ func useProperAttributeTypesInSet(rule *resourceDataSetRule) func(r *provparse.R
 			t = named.Underlying()
 		}
 
-		if kinds, ok := allowedBasicKind[att.Type]; ok {
-			if basic, ok := t.(*types.Basic); ok {
-				kindFound := false
-				for _, k := range kinds {
-					if basic.Kind() == k {
-						kindFound = true
-						break
-					}
-				}
-				if !kindFound {
-					// this is a basic but the wrong one
-					return wrongType()
-				}
-			} else {
-				// not a basic type when attribute expects it
+		if _, ok := allowedBasicKind[att.Type]; ok {
+			if !basicKindAllowed(att.Type, t) {
 				// TODO: trace output
 				return wrongType()
 			}
 			return nil, nil
 		}
 
-		rule.warnf("complex set type checking not yet implemented")
+		if _, ok := allowedBasicKind[att.ElemType]; !ok {
+			// nested blocks are not checked
+			rule.tracef("skipping set type checking of %q with elem type %v", attName, att.ElemType)
+			return nil, nil
+		}
 
-		// TODO: fill this in!
-		switch att.Type {
-		case provparse.TypeList:
-		case provparse.TypeSet:
-		case provparse.TypeMap:
+		var elem types.Type
+		switch t := t.(type) {
+		case *types.Basic:
+			// a primitive is never a valid collection
+			return wrongType()
+		case *types.Slice:
+			if att.Type == provparse.TypeMap {
+				return wrongType()
+			}
+			elem = t.Elem()
+		case *types.Array:
+			if att.Type == provparse.TypeMap {
+				return wrongType()
+			}
+			elem = t.Elem()
+		case *types.Map:
+			if att.Type != provparse.TypeMap {
+				return wrongType()
+			}
+			elem = t.Elem()
+		default:
+			// for example a *schema.Set
+			rule.tracef("skipping set type checking of %q for %T", attName, t)
+			return nil, nil
+		}
+
+		elem = ssahelp.DerefType(elem)
+		if named, ok := elem.(*types.Named); ok {
+			elem = named.Underlying()
+		}
+		if _, ok := elem.(*types.Interface); ok {
+			// elements are only known at runtime
+			return nil, nil
+		}
+		if !basicKindAllowed(att.ElemType, elem) {
+			return wrongType()
 		}
 
 		return nil, nil
 	}
 }
+
+// basicKindAllowed returns true if the type is a basic type allowed for the
+// primitive attribute type.
+func basicKindAllowed(attType provparse.AttributeType, t types.Type) bool {
+	basic, ok := t.(*types.Basic)
+	if !ok {
+		return false
+	}
+	for _, k := range allowedBasicKind[attType] {
+		if basic.Kind() == k {
+			return true
+		}
+	}
+	return false
+}
@@ -52,6 +52,60 @@ func TestUseProperAttributeTypesInSet(t *testing.T) {
 				t.Fatal(err)
 			}
 			assertIssueMsg(t, c.expectedMsg, actualIssues)
+			if len(actualIssues) > 0 {
+				if path := actualIssues[0].AttributePath; path != "att" {
+					t.Fatalf("unexpected attribute path %q", path)
+				}
+				if len(actualIssues[0].Related) != 1 {
+					t.Fatalf("expected the schema definition as a related location")
+				}
+			}
+		})
+	}
+}
+
+func TestUseProperAttributeTypesInSet_collections(t *testing.T) {
+	for i, c := range []struct {
+		expectedMsg   string
+		attributeType provparse.AttributeType
+		elemType      provparse.AttributeType
+		funcName      string
+	}{
+		{"", provparse.TypeList, provparse.TypeString, "setStringSlice"},
+		{"", provparse.TypeList, provparse.TypeString, "setPointerStringSlice"},
+		{"", provparse.TypeList, provparse.TypeString, "setNamedStringSlice"},
+		{"", provparse.TypeList, provparse.TypeString, "setInterfaceSlice"},
+		{"", provparse.TypeSet, provparse.TypeInt, "setIntSlice"},
+		{"", provparse.TypeSet, provparse.TypeInt, "setIntArray"},
+		{"", provparse.TypeMap, provparse.TypeString, "setStringMap"},
+		{"", provparse.TypeMap, provparse.TypeString, "setInterfaceMap"},
+
+		// nested blocks are not checked
+		{"", provparse.TypeList, provparse.TypeInvalid, "setString"},
+
+		{"attribute \"att\" expects a d.Set compatible with TypeList of TypeString", provparse.TypeList, provparse.TypeString, "setIntSlice"},
+		{"attribute \"att\" expects a d.Set compatible with TypeList of TypeString", provparse.TypeList, provparse.TypeString, "setString"},
+		{"attribute \"att\" expects a d.Set compatible with TypeList of TypeString", provparse.TypeList, provparse.TypeString, "setStringMap"},
+		{"attribute \"att\" expects a d.Set compatible with TypeSet of TypeInt", provparse.TypeSet, provparse.TypeInt, "setStringSlice"},
+		{"attribute \"att\" expects a d.Set compatible with TypeMap of TypeString", provparse.TypeMap, provparse.TypeString, "setStringSlice"},
+		{"attribute \"att\" expects a d.Set compatible with TypeMap of TypeString", provparse.TypeMap, provparse.TypeString, "setIntMap"},
+	} {
+		t.Run(fmt.Sprintf("%d %s", i, c.funcName), func(t *testing.T) {
+			r := &resourceDataSetRule{}
+			ci := lookupSetCallInstruction(useProperAttributeTypesPkg, c.funcName)
+			if ci == nil {
+				t.Fatalf("unable to find ssa.CallInstruction %q", c.funcName)
+			}
+			att := &provparse.Attribute{
+				Name:     "att",
+				Type:     c.attributeType,
+				ElemType: c.elemType,
+			}
+			actualIssues, err := useProperAttributeTypesInSet(r)(nil, att, "att", ci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertIssueMsg(t, c.expectedMsg, actualIssues)
 		})
 	}
 }
@@ -138,4 +192,40 @@ type MyInt int
 func setNamedInt(d *ResourceData, val MyInt) {
 	d.Set("att", val)
 }
+
+func setStringSlice(d *ResourceData, val []string) {
+	d.Set("att", val)
+}
+
+func setPointerStringSlice(d *ResourceData, val []*string) {
+	d.Set("att", val)
+}
+
+func setNamedStringSlice(d *ResourceData, val []MyString) {
+	d.Set("att", val)
+}
+
+func setInterfaceSlice(d *ResourceData, val []interface{}) {
+	d.Set("att", val)
+}
+
+func setIntSlice(d *ResourceData, val []int) {
+	d.Set("att", val)
+}
+
+func setIntArray(d *ResourceData, val [2]int) {
+	d.Set("att", val)
+}
+
+func setStringMap(d *ResourceData, val map[string]string) {
+	d.Set("att", val)
+}
+
+func setInterfaceMap(d *ResourceData, val map[string]interface{}) {
+	d.Set("att", val)
+}
+
+func setIntMap(d *ResourceData, val map[string]int) {
+	d.Set("att", val)
+}
 `)
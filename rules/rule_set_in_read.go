@@ -0,0 +1,37 @@
+package rules
+
+import (
+	"github.com/paultyng/tfprovlint/lint"
+	"github.com/paultyng/tfprovlint/provparse"
+)
+
+var SetInReadFuncRuleDoc = Documentation{
+	Rationale: `ReadFunc is responsible for refreshing state from the API. If it never calls
+d.Set, changes made outside of Terraform are not detected and imported
+resources have no attributes in state.`,
+	Bad: `func resourceExampleRead(d *schema.ResourceData, meta interface{}) error {
+	_, err := client.Get(d.Id())
+	return err
+}`,
+	Good: `func resourceExampleRead(d *schema.ResourceData, meta interface{}) error {
+	thing, err := client.Get(d.Id())
+	if err != nil {
+		return err
+	}
+	d.Set("name", thing.Name)
+	return nil
+}`,
+	FalsePositives: `Attributes set with helpers outside of the provider package, or set by
+other means such as d.SetPartial, are not found.`,
+}
+
+func NewSetInReadFuncRule() lint.ResourceRule {
+	return &callRequiredRule{
+		IssueMessageFormat: "ReadFunc should call %s",
+		Read:               stringSliceToSet([]string{funcResourceDataSet}),
+		// nothing needs to be set if there are no attributes in the schema
+		SkipResource: func(readOnly bool, r *provparse.Resource) bool {
+			return len(r.Attributes) == 0 && !r.PartialParse
+		},
+	}
+}
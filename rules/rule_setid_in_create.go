@@ -0,0 +1,30 @@
+package rules
+
+import "github.com/paultyng/tfprovlint/lint"
+
+var SetIdInCreateFuncRuleDoc = Documentation{
+	Rationale: `The SDK only saves a resource to state if it has an ID when CreateFunc
+returns. If d.SetId is never called the resource is created in the API but
+Terraform loses track of it, and it will be created again on the next apply.`,
+	Bad: `func resourceExampleCreate(d *schema.ResourceData, meta interface{}) error {
+	_, err := client.Create(d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	return resourceExampleRead(d, meta)
+}`,
+	Good: `func resourceExampleCreate(d *schema.ResourceData, meta interface{}) error {
+	thing, err := client.Create(d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+	d.SetId(thing.ID)
+	return resourceExampleRead(d, meta)
+}`,
+}
+
+func NewSetIdInCreateFuncRule() lint.ResourceRule {
+	return NewCallRequiredRule("CreateFunc should call %s", CallList{
+		Create: []string{funcResourceDataSetId},
+	})
+}
@@ -1,6 +1,24 @@
 package rules
 
-import "github.com/paultyng/tfprovlint/lint"
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/paultyng/tfprovlint/lint"
+)
+
+var NoErrwrapWrapfInResourceFuncRuleDoc = Documentation{
+	Rationale: `Terraform only displays the message of errors returned from resource
+functions, so the wrapping errwrap.Wrapf provides is never used. fmt.Errorf
+is the standard way of adding context to an error and avoids the {{err}}
+template syntax.`,
+	Bad:  `return errwrap.Wrapf("error reading example: {{err}}", err)`,
+	Good: `return fmt.Errorf("error reading example: %s", err)`,
+}
 
 func NewNoErrwrapWrapfInResourceFuncRule() lint.ResourceRule {
 	funcBlacklist := map[string]bool{}
@@ -13,5 +31,41 @@ func NewNoErrwrapWrapfInResourceFuncRule() lint.ResourceRule {
 		Exists:             funcBlacklist,
 		Read:               funcBlacklist,
 		Update:             funcBlacklist,
+		SuggestFixes:       replaceErrwrapWrapf,
+	}
+}
+
+// replaceErrwrapWrapf suggests rewriting errwrap.Wrapf("... {{err}}", err) to
+// fmt.Errorf("... %s", err). Only literal formats with a single {{err}} are
+// rewritten, as errwrap does not support format verbs.
+func replaceErrwrapWrapf(ssacall ssa.CallInstruction) []lint.SuggestedFix {
+	call, _ := callSyntax(ssacall)
+	if call == nil || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+		return nil
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil
+	}
+	format, err := strconv.Unquote(lit.Value)
+	if err != nil || strings.Count(format, "{{err}}") != 1 {
+		return nil
+	}
+
+	format = strings.Replace(format, "%", "%%", -1)
+	format = strings.Replace(format, "{{err}}", "%s", 1)
+	newLit := strconv.Quote(format)
+	if strings.HasPrefix(lit.Value, "`") {
+		newLit = "`" + format + "`"
+	}
+
+	return []lint.SuggestedFix{
+		{
+			Message: "Replace errwrap.Wrapf with fmt.Errorf",
+			Edits: []lint.TextEdit{
+				{Pos: call.Fun.Pos(), End: call.Fun.End(), NewText: []byte("fmt.Errorf")},
+				{Pos: lit.Pos(), End: lit.End(), NewText: []byte(newLit)},
+			},
+		},
 	}
 }
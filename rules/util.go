@@ -55,11 +55,29 @@ func normalizeSSAFunctionString(f *ssa.Function) string {
 		return fmt.Sprintf("(%s).%s", typeName, funcName)
 	}
 
-	pkgPath := ssahelp.NormalizePkgPath(f.Pkg.Pkg)
+	// synthetic functions, such as wrappers, have no package
+	pkg := f.Pkg
+	if pkg == nil {
+		obj := f.Object()
+		if obj == nil || obj.Pkg() == nil {
+			return f.String()
+		}
+		return fmt.Sprintf("%s.%s", ssahelp.NormalizePkgPath(obj.Pkg()), funcName)
+	}
+
+	pkgPath := ssahelp.NormalizePkgPath(pkg.Pkg)
 
 	return fmt.Sprintf("%s.%s", pkgPath, funcName)
 }
 
+func stringSliceToSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
 func numStars(v types.Type) int {
 	stars := 0
 	ptr, ok := v.(*types.Pointer)
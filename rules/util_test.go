@@ -31,14 +31,6 @@ func assertIssueMsg(t *testing.T, expectedMsg string, issues []lint.Issue) {
 	}
 }
 
-func stringSliceToSet(values []string) map[string]bool {
-	set := make(map[string]bool, len(values))
-	for _, v := range values {
-		set[v] = true
-	}
-	return set
-}
-
 func mustMakeSamplePkg(src string) *ssa.Package {
 	pkg, err := makeSamplePkg(src)
 	if err != nil {
@@ -3,6 +3,7 @@ package ssahelp
 import (
 	"bytes"
 	"fmt"
+	"go/constant"
 	"go/token"
 	"go/types"
 	"strconv"
@@ -226,6 +227,85 @@ func StructFieldBoolValue(instrs []ssa.Instruction, structType, fieldName string
 	}
 }
 
+func StructFieldIntValue(instrs []ssa.Instruction, structType, fieldName string) (int, error) {
+	v, err := StructFieldValue(instrs, structType, fieldName)
+	if err != nil {
+		return 0, err
+	}
+	v = RootValue(v)
+	if c, ok := v.(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.Int {
+		return int(c.Int64()), nil
+	}
+	return 0, &ErrNoExpectedValueFound{
+		Found: v,
+	}
+}
+
+// StructFieldStringSliceValue returns the value of a []string field set to a
+// slice literal of constants, or nil.
+func StructFieldStringSliceValue(instrs []ssa.Instruction, structType, fieldName string) ([]string, error) {
+	v, err := StructFieldValue(instrs, structType, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	v = RootValue(v)
+	switch v := v.(type) {
+	case *ssa.Const:
+		if v.IsNil() {
+			return nil, nil
+		}
+	case *ssa.Slice:
+		if values, ok := stringArrayValues(v.X); ok {
+			return values, nil
+		}
+	}
+	return nil, &ErrNoExpectedValueFound{
+		Found: v,
+	}
+}
+
+// stringArrayValues returns the elements of the array allocated for a slice
+// literal, ok is false if any element is not a string constant.
+func stringArrayValues(v ssa.Value) ([]string, bool) {
+	alloc, ok := v.(*ssa.Alloc)
+	if !ok {
+		return nil, false
+	}
+	arr, ok := DerefType(alloc.Type()).Underlying().(*types.Array)
+	if !ok {
+		return nil, false
+	}
+
+	values := make([]string, arr.Len())
+	found := 0
+	for _, ref := range *alloc.Referrers() {
+		indexAddr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		index, ok := indexAddr.Index.(*ssa.Const)
+		if !ok {
+			return nil, false
+		}
+		for _, indexRef := range *indexAddr.Referrers() {
+			store, ok := indexRef.(*ssa.Store)
+			if !ok || store.Addr != indexAddr {
+				continue
+			}
+			c, ok := RootValue(store.Val).(*ssa.Const)
+			if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+				return nil, false
+			}
+			values[index.Int64()] = constant.StringVal(c.Value)
+			found++
+		}
+	}
+	if found != len(values) {
+		return nil, false
+	}
+	return values, true
+}
+
 func StructFieldFuncValue(instrs []ssa.Instruction, structType, fieldName string) (*ssa.Function, error) {
 	v, err := StructFieldValue(instrs, structType, fieldName)
 	if err != nil {
@@ -241,6 +321,44 @@ func StructFieldFuncValue(instrs []ssa.Instruction, structType, fieldName string
 	}
 }
 
+// StructFieldFuncCallValue returns the function value of a field, and the
+// arguments of the call that returned it, if any. For example a field set to
+// validation.StringInSlice(values, false) returns the closure created by
+// StringInSlice and the values passed to it. A nil function is returned if the
+// field is set to nil.
+func StructFieldFuncCallValue(instrs []ssa.Instruction, structType, fieldName string) (*ssa.Function, []ssa.Value, error) {
+	v, err := StructFieldValue(instrs, structType, fieldName)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := RootValuePath(v)
+	if len(path) == 0 {
+		return nil, nil, &ErrNoExpectedValueFound{}
+	}
+
+	var args []ssa.Value
+	for _, pv := range path {
+		if call, ok := pv.(*ssa.Call); ok {
+			args = call.Call.Args
+			break
+		}
+	}
+
+	switch root := path[len(path)-1].(type) {
+	case *ssa.Function:
+		return root, args, nil
+	case *ssa.Const:
+		if root.IsNil() {
+			return nil, nil, nil
+		}
+	}
+	return nil, nil, &ErrNoExpectedValueFound{
+		Found: path[len(path)-1],
+	}
+}
+
+// FieldAddrValue returns the value stored to the field address, or nil if the
+// field is only read.
 func FieldAddrValue(fieldAddr *ssa.FieldAddr) ssa.Value {
 	var store *ssa.Store
 	InspectInstructions(*fieldAddr.Referrers(), func(ins ssa.Instruction) bool {
@@ -250,6 +368,9 @@ func FieldAddrValue(fieldAddr *ssa.FieldAddr) ssa.Value {
 		}
 		return true
 	})
+	if store == nil {
+		return nil
+	}
 	return store.Val
 }
 
@@ -283,8 +404,11 @@ func StructFieldValue(instrs []ssa.Instruction, structType, fieldName string) (s
 		if field == nil || field.Name() != fieldName {
 			return true
 		}
-		v = FieldAddrValue(fieldAddr)
-		return v != nil
+		// the last value stored is used, reads of the field are skipped
+		if fieldVal := FieldAddrValue(fieldAddr); fieldVal != nil {
+			v = fieldVal
+		}
+		return true
 	})
 	if v == nil {
 		return nil, &ErrNoFieldAddrFound{}
@@ -0,0 +1,14 @@
+// Command vettool runs the tfprovlint rules with go vet:
+//
+//	go vet -vettool=$(which vettool) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/paultyng/tfprovlint/cmd"
+)
+
+func main() {
+	unitchecker.Main(cmd.Analyzers()...)
+}